@@ -3,6 +3,8 @@ package v1beta1
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -17,6 +19,7 @@ type NodeMonitorType string
 const (
 	NodeMonitorTypeNone                    = "None"
 	NodeMonitorTypeFake                    = "Fake"
+	NodeMonitorTypeMetricsAPI              = "MetricsAPI"
 	NodeMonitorTypeIPMIExporter            = "IPMIExporter"
 	NodeMonitorTypeRedfish                 = "Redfish"
 	NodeMonitorTypeDifferentialPressureAPI = "DifferentialPressureAPI"
@@ -25,11 +28,77 @@ const (
 type NodeMonitorAgent struct {
 	Type     NodeMonitorType `json:"type"`
 	Endpoint string          `json:"endpoint,omitempty"`
+	// BasicAuthSecret refers to a Secret in the same namespace as the Estimator,
+	// the Secret must have "username" and "password" keys.
+	BasicAuthSecret *corev1.LocalObjectReference `json:"basicAuthSecret,omitempty"`
+	// InsecureSkipVerify disables TLS certificate verification (e.g. BMCs with self-signed certificates).
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// HTTPClient configures timeouts, retries and the circuit breaker of the HTTP requests sent by the agent.
+	HTTPClient *HTTPClientConfig `json:"httpClient,omitempty"`
+
+	IPMIExporter *IPMIExporterConfig `json:"ipmiExporter,omitempty"`
+}
+
+// HTTPClientConfig configures the HTTP requests sent by NodeMonitors and PowerConsumptionPredictors.
+type HTTPClientConfig struct {
+	// Timeout limits each attempt if specified.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// MaxRetries is the number of retries after connection errors and 5xx responses, defaults to 0.
+	//+kubebuilder:validation:Minimum=0
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// RetryBackoff is the wait before the first retry, doubled for each retry, defaults to 100ms.
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+	// CircuitBreaker fails requests fast after consecutive failures if specified.
+	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit, defaults to 5.
+	//+kubebuilder:validation:Minimum=0
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDuration is how long requests are failed fast before a trial request, defaults to 30s.
+	OpenDuration *metav1.Duration `json:"openDuration,omitempty"`
+}
+
+type IPMIExporterConfig struct {
+	// InletTempSensor is a regular expression matched against the "name" label of ipmi_temperature_celsius
+	// to choose the inlet temperature sensor, defaults to `(?i)inlet|ambient`.
+	InletTempSensor string `json:"inletTempSensor,omitempty"`
 }
 
 type NodeMonitor struct {
 	RefreshInterval *metav1.Duration   `json:"refreshInterval,omitempty"`
 	Agents          []NodeMonitorAgent `json:"agents"`
+	// MaxAge limits how long the NodeStatus values are used per key (e.g. "ambientTemp": "5m"),
+	// the last-known-good values are kept when the agents fail and are rejected as stale once they get older.
+	// Defaults to 3 times RefreshInterval, "0s" means unlimited.
+	MaxAge map[string]metav1.Duration `json:"maxAge,omitempty"`
+	// HistorySize is the number of NodeStatus snapshots kept per node, defaults to 10.
+	//+kubebuilder:validation:Minimum=1
+	HistorySize *int `json:"historySize,omitempty"`
+	// Aggregation smooths the NodeStatus values per key over the history before they are passed to the predictor,
+	// e.g. "staticPressureDiff": {"method": "Median", "window": 5}.
+	Aggregation map[string]NodeStatusAggregation `json:"aggregation,omitempty"`
+}
+
+type NodeStatusAggregationMethod string
+
+const (
+	NodeStatusAggregationLast   = "Last"
+	NodeStatusAggregationEWMA   = "EWMA"
+	NodeStatusAggregationMedian = "Median"
+)
+
+// NodeStatusAggregation specifies how the values of a key are aggregated, only float values are aggregated.
+type NodeStatusAggregation struct {
+	// Method defaults to Last.
+	//+kubebuilder:validation:Enum=Last;EWMA;Median
+	Method NodeStatusAggregationMethod `json:"method,omitempty"`
+	// Window is the number of the latest values aggregated, defaults to all the values in the history.
+	//+kubebuilder:validation:Minimum=1
+	Window *int `json:"window,omitempty"`
+	// Alpha is the smoothing factor of EWMA in (0, 1], defaults to "0.5".
+	Alpha *resource.Quantity `json:"alpha,omitempty"`
 }
 
 type PowerConsumptionPredictorType string
@@ -38,12 +107,164 @@ const (
 	PowerConsumptionPredictorTypeNone     = "None"
 	PowerConsumptionPredictorTypeFake     = "Fake"
 	PowerConsumptionPredictorTypeMLServer = "MLServer"
-	// PowerConsumptionPredictorTypeTFServing = "TFServing"
+	// PowerConsumptionPredictorTypeMLServerGRPC uses the V2 inference protocol over gRPC,
+	// the endpoint is in {grpc|grpcs}://{host:port}/v2/models/{model}/versions/{version} format.
+	PowerConsumptionPredictorTypeMLServerGRPC = "MLServerGRPC"
+	// PowerConsumptionPredictorTypeTFServing uses the TensorFlow Serving REST predict API,
+	// the endpoint is in {scheme+server}/v1/models/{model}[/versions/{version}][:predict] format.
+	PowerConsumptionPredictorTypeTFServing = "TFServing"
+	// PowerConsumptionPredictorTypePowerCurve interpolates a power curve, the endpoint is ignored.
+	PowerConsumptionPredictorTypePowerCurve = "PowerCurve"
+	// PowerConsumptionPredictorTypePolynomial evaluates a polynomial over NodeStatus values, the endpoint is ignored.
+	PowerConsumptionPredictorTypePolynomial = "Polynomial"
+	// PowerConsumptionPredictorTypeEnsemble combines the predictions of the members in ensemble, the endpoint is ignored.
+	// Only the primary predictor can be an ensemble (not the fallbacks).
+	PowerConsumptionPredictorTypeEnsemble = "Ensemble"
 )
 
 type PowerConsumptionPredictor struct {
+	PowerConsumptionPredictorSpec `json:",inline"`
+
+	// Ensemble specifies the members combined if Type is Ensemble.
+	Ensemble *EnsembleConfig `json:"ensemble,omitempty"`
+
+	// Fallbacks are tried in order when the predictor above fails (errors, times out or returns +Inf),
+	// the responses of the Estimator API include the name of the predictor used for each node.
+	Fallbacks []PowerConsumptionPredictorSpec `json:"fallbacks,omitempty"`
+}
+
+type PowerConsumptionPredictorSpec struct {
 	Type     PowerConsumptionPredictorType `json:"type"`
 	Endpoint string                        `json:"endpoint,omitempty"`
+	// Name is reported as the predictor used in the responses of the Estimator API, defaults to the type.
+	Name string `json:"name,omitempty"`
+	// Timeout is applied to each prediction if specified, the next predictor in Fallbacks is tried on timeout.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// HTTPClient configures timeouts, retries and the circuit breaker of the HTTP requests (MLServer and TFServing).
+	HTTPClient *HTTPClientConfig `json:"httpClient,omitempty"`
+	// ErrorBounds adds the known errors of the predictor to its predictions,
+	// the bounds of the power increases are reported in the responses of the Estimator API.
+	ErrorBounds *ErrorBoundsConfig `json:"errorBounds,omitempty"`
+
+	MLServer  *MLServerConfig  `json:"mlServer,omitempty"`
+	TFServing *TFServingConfig `json:"tfServing,omitempty"`
+
+	PowerCurve *PowerCurveConfig `json:"powerCurve,omitempty"`
+	Polynomial *PolynomialConfig `json:"polynomial,omitempty"`
+}
+
+// MLServerConfig configures the input tensor sent to MLServer (both MLServer and MLServerGRPC).
+type MLServerConfig struct {
+	// Features is an ordered list of NodeStatus keys used as the columns of the input tensor,
+	// defaults to ["cpuUsage", "ambientTemp", "staticPressureDiff"].
+	// The requested CPU is added to "cpuUsage".
+	// "requestMemoryBytes" and "requestExtendedResources/{name}" are filled with the requested resources.
+	Features []string `json:"features,omitempty"`
+	// InputName is the name of the input tensor, defaults to "predict-prob".
+	InputName string `json:"inputName,omitempty"`
+	// Datatype is the datatype of the input tensor, defaults to "FP32".
+	//+kubebuilder:validation:Enum=FP16;FP32;FP64
+	Datatype string `json:"datatype,omitempty"`
+	// OutputIndex chooses the output tensor used as the prediction, defaults to 0.
+	//+kubebuilder:validation:Minimum=0
+	OutputIndex int `json:"outputIndex,omitempty"`
+}
+
+// TFServingConfig configures the instances sent to TensorFlow Serving.
+type TFServingConfig struct {
+	// Features is an ordered list of NodeStatus keys used as the values of each instance,
+	// defaults to ["cpuUsage", "ambientTemp", "staticPressureDiff"].
+	// The requested CPU is added to "cpuUsage".
+	// "requestMemoryBytes" and "requestExtendedResources/{name}" are filled with the requested resources.
+	Features []string `json:"features,omitempty"`
+	// InputName sends each instance as {inputName: [values]} if specified.
+	InputName string `json:"inputName,omitempty"`
+	// SignatureName is the signature of the model, defaults to "serving_default".
+	SignatureName string `json:"signatureName,omitempty"`
+	// OutputIndex chooses the value used as the prediction if each prediction is an array, defaults to 0.
+	//+kubebuilder:validation:Minimum=0
+	OutputIndex int `json:"outputIndex,omitempty"`
+}
+
+// PowerCurveConfig specifies a power curve (e.g. SPECpower results) interpolated linearly.
+type PowerCurveConfig struct {
+	// Points is the power curve, Points or ConfigMapKeyRef must be specified.
+	Points []PowerCurvePoint `json:"points,omitempty"`
+	// ConfigMapKeyRef refers to a key of a ConfigMap in the same namespace as the Estimator,
+	// the value is CSV with "{cpuUsage},{watts}" lines (e.g. "0,50.5\n100,200").
+	// This is used if Points is empty.
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// AmbientTempCorrection adds (ambientTemp - referenceTemp) * wattsPerDegree if specified.
+	AmbientTempCorrection *AmbientTempCorrection `json:"ambientTempCorrection,omitempty"`
+}
+
+type PowerCurvePoint struct {
+	// CPUUsage is CPU utilization in percent.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	CPUUsage int `json:"cpuUsage"`
+	// Watts is the power consumption at CPUUsage, e.g. "52.5".
+	Watts resource.Quantity `json:"watts"`
+}
+
+type AmbientTempCorrection struct {
+	// ReferenceTemp is the ambient temperature in Celsius at which the power curve was measured, e.g. "25".
+	ReferenceTemp resource.Quantity `json:"referenceTemp"`
+	// WattsPerDegree is the increase of power consumption per degree Celsius, e.g. "0.8".
+	WattsPerDegree resource.Quantity `json:"wattsPerDegree"`
+}
+
+// PolynomialConfig specifies a polynomial evaluated in-process,
+// intercept + sum(terms[i].coefficient * prod(feature^power)).
+type PolynomialConfig struct {
+	// Intercept is the constant term, e.g. "40.5".
+	Intercept resource.Quantity `json:"intercept,omitempty"`
+	Terms     []PolynomialTerm  `json:"terms,omitempty"`
+}
+
+type PolynomialTerm struct {
+	// Coefficient of the term, e.g. "0.85".
+	Coefficient resource.Quantity `json:"coefficient"`
+	// Powers maps NodeStatus keys to their powers, e.g. {"cpuUsage": 2, "ambientTemp": 1}.
+	// The requested CPU is added to "cpuUsage".
+	// "requestMemoryBytes" and "requestExtendedResources/{name}" are filled with the requested resources.
+	Powers map[string]int `json:"powers"`
+}
+
+// ErrorBoundsConfig specifies the errors of a predictor, e.g. measured on a validation data set.
+// The bounds are prediction -/+ (absolute + relative * |prediction|).
+type ErrorBoundsConfig struct {
+	// Absolute is the error in watts, e.g. "5".
+	Absolute resource.Quantity `json:"absolute,omitempty"`
+	// Relative is the error in ratio of the predictions, e.g. "0.05".
+	Relative resource.Quantity `json:"relative,omitempty"`
+}
+
+type EnsembleStrategy string
+
+const (
+	EnsembleStrategyMean         = "Mean"
+	EnsembleStrategyWeightedMean = "WeightedMean"
+	EnsembleStrategyMin          = "Min"
+	EnsembleStrategyMax          = "Max"
+)
+
+// EnsembleConfig specifies the members of an ensemble and how their predictions are combined,
+// e.g. to canary a new model version side by side with the current one.
+type EnsembleConfig struct {
+	// Strategy defaults to Mean.
+	//+kubebuilder:validation:Enum=Mean;WeightedMean;Min;Max
+	Strategy EnsembleStrategy `json:"strategy,omitempty"`
+	//+kubebuilder:validation:MinItems=1
+	Members []EnsembleMember `json:"members"`
+}
+
+type EnsembleMember struct {
+	PowerConsumptionPredictorSpec `json:",inline"`
+
+	// Weight is used by WeightedMean, defaults to 1, e.g. "0.1".
+	Weight *resource.Quantity `json:"weight,omitempty"`
 }
 
 type NodeConfig struct {
@@ -51,10 +272,22 @@ type NodeConfig struct {
 	PowerConsumptionPredictor *PowerConsumptionPredictor `json:"powerConsumptionPredictor,omitempty"`
 }
 
+// PredictionCacheConfig enables the cache of predictions shared by the nodes,
+// NodeStatus only changes every refreshInterval so repeated requests within an interval are served from memory.
+type PredictionCacheConfig struct {
+	// Size is the maximum number of cached predictions, defaults to 10000.
+	//+kubebuilder:validation:Minimum=0
+	Size int `json:"size,omitempty"`
+	// TTL is the lifetime of cached predictions, defaults to 30s.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
 // EstimatorSpec defines the desired state of Estimator
 type EstimatorSpec struct {
 	DefaultNodeConfig   *NodeConfig            `json:"defaultNodeConfig,omitempty"`
 	NodeConfigOverrides map[string]*NodeConfig `json:"nodeConfigOverrides,omitempty"`
+	// PredictionCache enables the prediction cache if specified.
+	PredictionCache *PredictionCacheConfig `json:"predictionCache,omitempty"`
 }
 
 func (r *Estimator) MergeNodeConfig(nodeName string) *NodeConfig {
@@ -78,6 +311,15 @@ func (r *Estimator) MergeNodeConfig(nodeName string) *NodeConfig {
 		if len(overrides.NodeMonitor.Agents) != 0 {
 			merged.NodeMonitor.Agents = overrides.NodeMonitor.Agents
 		}
+		if len(overrides.NodeMonitor.MaxAge) != 0 {
+			merged.NodeMonitor.MaxAge = overrides.NodeMonitor.MaxAge
+		}
+		if overrides.NodeMonitor.HistorySize != nil {
+			merged.NodeMonitor.HistorySize = overrides.NodeMonitor.HistorySize
+		}
+		if len(overrides.NodeMonitor.Aggregation) != 0 {
+			merged.NodeMonitor.Aggregation = overrides.NodeMonitor.Aggregation
+		}
 	}
 	// override PowerConsumptionPredictor
 	if overrides.PowerConsumptionPredictor != nil {
@@ -87,6 +329,36 @@ func (r *Estimator) MergeNodeConfig(nodeName string) *NodeConfig {
 		if overrides.PowerConsumptionPredictor.Endpoint != "" {
 			merged.PowerConsumptionPredictor.Endpoint = overrides.PowerConsumptionPredictor.Endpoint
 		}
+		if overrides.PowerConsumptionPredictor.Name != "" {
+			merged.PowerConsumptionPredictor.Name = overrides.PowerConsumptionPredictor.Name
+		}
+		if overrides.PowerConsumptionPredictor.Timeout != nil {
+			merged.PowerConsumptionPredictor.Timeout = overrides.PowerConsumptionPredictor.Timeout
+		}
+		if overrides.PowerConsumptionPredictor.MLServer != nil {
+			merged.PowerConsumptionPredictor.MLServer = overrides.PowerConsumptionPredictor.MLServer
+		}
+		if overrides.PowerConsumptionPredictor.TFServing != nil {
+			merged.PowerConsumptionPredictor.TFServing = overrides.PowerConsumptionPredictor.TFServing
+		}
+		if overrides.PowerConsumptionPredictor.PowerCurve != nil {
+			merged.PowerConsumptionPredictor.PowerCurve = overrides.PowerConsumptionPredictor.PowerCurve
+		}
+		if overrides.PowerConsumptionPredictor.Polynomial != nil {
+			merged.PowerConsumptionPredictor.Polynomial = overrides.PowerConsumptionPredictor.Polynomial
+		}
+		if overrides.PowerConsumptionPredictor.HTTPClient != nil {
+			merged.PowerConsumptionPredictor.HTTPClient = overrides.PowerConsumptionPredictor.HTTPClient
+		}
+		if overrides.PowerConsumptionPredictor.ErrorBounds != nil {
+			merged.PowerConsumptionPredictor.ErrorBounds = overrides.PowerConsumptionPredictor.ErrorBounds
+		}
+		if overrides.PowerConsumptionPredictor.Ensemble != nil {
+			merged.PowerConsumptionPredictor.Ensemble = overrides.PowerConsumptionPredictor.Ensemble
+		}
+		if len(overrides.PowerConsumptionPredictor.Fallbacks) != 0 {
+			merged.PowerConsumptionPredictor.Fallbacks = overrides.PowerConsumptionPredictor.Fallbacks
+		}
 	}
 
 	return merged
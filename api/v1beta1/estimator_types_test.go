@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,8 +24,10 @@ var (
 			}},
 		},
 		PowerConsumptionPredictor: &PowerConsumptionPredictor{
-			Type:     PowerConsumptionPredictorTypeFake,
-			Endpoint: "baz",
+			PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+				Type:     PowerConsumptionPredictorTypeFake,
+				Endpoint: "baz",
+			},
 		},
 	}
 	node3NodeConf = &NodeConfig{
@@ -38,10 +41,83 @@ var (
 			}},
 		},
 		PowerConsumptionPredictor: &PowerConsumptionPredictor{
-			Type:     PowerConsumptionPredictorTypeNone,
-			Endpoint: "fuga",
+			PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+				Type:     PowerConsumptionPredictorTypeNone,
+				Endpoint: "fuga",
+			},
+		},
+	}
+	node4MLServerConf = &MLServerConfig{
+		Features:    []string{"cpuUsage", "ambientTemp"},
+		InputName:   "input-0",
+		Datatype:    "FP64",
+		OutputIndex: 1,
+	}
+	node5TFServingConf = &TFServingConfig{
+		Features:      []string{"cpuUsage"},
+		InputName:     "input_1",
+		SignatureName: "predict",
+		OutputIndex:   1,
+	}
+	node5NodeConf = &NodeConfig{
+		NodeMonitor: defaultNodeConf.NodeMonitor,
+		PowerConsumptionPredictor: &PowerConsumptionPredictor{
+			PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+				Type:      PowerConsumptionPredictorTypeTFServing,
+				Endpoint:  "baz",
+				TFServing: node5TFServingConf,
+			},
+		},
+	}
+	node6Fallbacks = []PowerConsumptionPredictorSpec{{
+		Type: PowerConsumptionPredictorTypePolynomial,
+		Name: "linear",
+		Polynomial: &PolynomialConfig{
+			Intercept: resource.MustParse("50"),
+		},
+	}}
+	node6ErrorBounds = &ErrorBoundsConfig{
+		Absolute: resource.MustParse("5"),
+	}
+	node6NodeConf = &NodeConfig{
+		NodeMonitor: defaultNodeConf.NodeMonitor,
+		PowerConsumptionPredictor: &PowerConsumptionPredictor{
+			PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+				Type:        PowerConsumptionPredictorTypeMLServer,
+				Endpoint:    "baz",
+				Timeout:     &metav1.Duration{Duration: time.Second},
+				ErrorBounds: node6ErrorBounds,
+			},
+			Fallbacks: node6Fallbacks,
 		},
 	}
+	node4NodeConf = &NodeConfig{
+		NodeMonitor: defaultNodeConf.NodeMonitor,
+		PowerConsumptionPredictor: &PowerConsumptionPredictor{
+			PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+				Type:     PowerConsumptionPredictorTypeFake,
+				Endpoint: "baz",
+				MLServer: node4MLServerConf,
+			},
+		},
+	}
+	node7MaxAge = map[string]metav1.Duration{
+		"ambientTemp": {Duration: 5 * time.Minute},
+	}
+	node7HistorySize = 20
+	node7Aggregation = map[string]NodeStatusAggregation{
+		"staticPressureDiff": {Method: NodeStatusAggregationMedian},
+	}
+	node7NodeConf = &NodeConfig{
+		NodeMonitor: &NodeMonitor{
+			RefreshInterval: defaultNodeConf.NodeMonitor.RefreshInterval,
+			Agents:          defaultNodeConf.NodeMonitor.Agents,
+			MaxAge:          node7MaxAge,
+			HistorySize:     &node7HistorySize,
+			Aggregation:     node7Aggregation,
+		},
+		PowerConsumptionPredictor: defaultNodeConf.PowerConsumptionPredictor,
+	}
 	estConf = Estimator{
 		Spec: EstimatorSpec{
 			DefaultNodeConfig: defaultNodeConf,
@@ -56,6 +132,38 @@ var (
 					PowerConsumptionPredictor: &PowerConsumptionPredictor{},
 				},
 				"node3": node3NodeConf,
+				"node4": {
+					PowerConsumptionPredictor: &PowerConsumptionPredictor{
+						PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+							MLServer: node4MLServerConf,
+						},
+					},
+				},
+				"node5": {
+					PowerConsumptionPredictor: &PowerConsumptionPredictor{
+						PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+							Type:      PowerConsumptionPredictorTypeTFServing,
+							TFServing: node5TFServingConf,
+						},
+					},
+				},
+				"node6": {
+					PowerConsumptionPredictor: &PowerConsumptionPredictor{
+						PowerConsumptionPredictorSpec: PowerConsumptionPredictorSpec{
+							Type:        PowerConsumptionPredictorTypeMLServer,
+							Timeout:     &metav1.Duration{Duration: time.Second},
+							ErrorBounds: node6ErrorBounds,
+						},
+						Fallbacks: node6Fallbacks,
+					},
+				},
+				"node7": {
+					NodeMonitor: &NodeMonitor{
+						MaxAge:      node7MaxAge,
+						HistorySize: &node7HistorySize,
+						Aggregation: node7Aggregation,
+					},
+				},
 			},
 		},
 	}
@@ -76,6 +184,10 @@ func TestEstimator_MergeNodeConfig(t *testing.T) {
 		{"node1", estConf, "node1", defaultNodeConf},
 		{"node2", estConf, "node2", defaultNodeConf},
 		{"node3", estConf, "node3", node3NodeConf},
+		{"node4", estConf, "node4", node4NodeConf},
+		{"node5", estConf, "node5", node5NodeConf},
+		{"node6", estConf, "node6", node6NodeConf},
+		{"node7", estConf, "node7", node7NodeConf},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -6,10 +6,108 @@
 package v1beta1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AmbientTempCorrection) DeepCopyInto(out *AmbientTempCorrection) {
+	*out = *in
+	out.ReferenceTemp = in.ReferenceTemp.DeepCopy()
+	out.WattsPerDegree = in.WattsPerDegree.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AmbientTempCorrection.
+func (in *AmbientTempCorrection) DeepCopy() *AmbientTempCorrection {
+	if in == nil {
+		return nil
+	}
+	out := new(AmbientTempCorrection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerConfig) DeepCopyInto(out *CircuitBreakerConfig) {
+	*out = *in
+	if in.OpenDuration != nil {
+		in, out := &in.OpenDuration, &out.OpenDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerConfig.
+func (in *CircuitBreakerConfig) DeepCopy() *CircuitBreakerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnsembleConfig) DeepCopyInto(out *EnsembleConfig) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]EnsembleMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnsembleConfig.
+func (in *EnsembleConfig) DeepCopy() *EnsembleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnsembleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnsembleMember) DeepCopyInto(out *EnsembleMember) {
+	*out = *in
+	in.PowerConsumptionPredictorSpec.DeepCopyInto(&out.PowerConsumptionPredictorSpec)
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnsembleMember.
+func (in *EnsembleMember) DeepCopy() *EnsembleMember {
+	if in == nil {
+		return nil
+	}
+	out := new(EnsembleMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorBoundsConfig) DeepCopyInto(out *ErrorBoundsConfig) {
+	*out = *in
+	out.Absolute = in.Absolute.DeepCopy()
+	out.Relative = in.Relative.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorBoundsConfig.
+func (in *ErrorBoundsConfig) DeepCopy() *ErrorBoundsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorBoundsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Estimator) DeepCopyInto(out *Estimator) {
 	*out = *in
@@ -92,6 +190,11 @@ func (in *EstimatorSpec) DeepCopyInto(out *EstimatorSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PredictionCache != nil {
+		in, out := &in.PredictionCache, &out.PredictionCache
+		*out = new(PredictionCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EstimatorSpec.
@@ -119,6 +222,71 @@ func (in *EstimatorStatus) DeepCopy() *EstimatorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPClientConfig) DeepCopyInto(out *HTTPClientConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPClientConfig.
+func (in *HTTPClientConfig) DeepCopy() *HTTPClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPMIExporterConfig) DeepCopyInto(out *IPMIExporterConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPMIExporterConfig.
+func (in *IPMIExporterConfig) DeepCopy() *IPMIExporterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPMIExporterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MLServerConfig) DeepCopyInto(out *MLServerConfig) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLServerConfig.
+func (in *MLServerConfig) DeepCopy() *MLServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MLServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
 	*out = *in
@@ -130,7 +298,7 @@ func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
 	if in.PowerConsumptionPredictor != nil {
 		in, out := &in.PowerConsumptionPredictor, &out.PowerConsumptionPredictor
 		*out = new(PowerConsumptionPredictor)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -149,13 +317,34 @@ func (in *NodeMonitor) DeepCopyInto(out *NodeMonitor) {
 	*out = *in
 	if in.RefreshInterval != nil {
 		in, out := &in.RefreshInterval, &out.RefreshInterval
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.Agents != nil {
 		in, out := &in.Agents, &out.Agents
 		*out = make([]NodeMonitorAgent, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = make(map[string]metav1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HistorySize != nil {
+		in, out := &in.HistorySize, &out.HistorySize
+		*out = new(int)
+		**out = **in
+	}
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = make(map[string]NodeStatusAggregation, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
 	}
 }
 
@@ -172,6 +361,21 @@ func (in *NodeMonitor) DeepCopy() *NodeMonitor {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeMonitorAgent) DeepCopyInto(out *NodeMonitorAgent) {
 	*out = *in
+	if in.BasicAuthSecret != nil {
+		in, out := &in.BasicAuthSecret, &out.BasicAuthSecret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(HTTPClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPMIExporter != nil {
+		in, out := &in.IPMIExporter, &out.IPMIExporter
+		*out = new(IPMIExporterConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMonitorAgent.
@@ -184,9 +388,93 @@ func (in *NodeMonitorAgent) DeepCopy() *NodeMonitorAgent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatusAggregation) DeepCopyInto(out *NodeStatusAggregation) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(int)
+		**out = **in
+	}
+	if in.Alpha != nil {
+		in, out := &in.Alpha, &out.Alpha
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatusAggregation.
+func (in *NodeStatusAggregation) DeepCopy() *NodeStatusAggregation {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatusAggregation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolynomialConfig) DeepCopyInto(out *PolynomialConfig) {
+	*out = *in
+	out.Intercept = in.Intercept.DeepCopy()
+	if in.Terms != nil {
+		in, out := &in.Terms, &out.Terms
+		*out = make([]PolynomialTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolynomialConfig.
+func (in *PolynomialConfig) DeepCopy() *PolynomialConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PolynomialConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolynomialTerm) DeepCopyInto(out *PolynomialTerm) {
+	*out = *in
+	out.Coefficient = in.Coefficient.DeepCopy()
+	if in.Powers != nil {
+		in, out := &in.Powers, &out.Powers
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolynomialTerm.
+func (in *PolynomialTerm) DeepCopy() *PolynomialTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(PolynomialTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PowerConsumptionPredictor) DeepCopyInto(out *PowerConsumptionPredictor) {
 	*out = *in
+	in.PowerConsumptionPredictorSpec.DeepCopyInto(&out.PowerConsumptionPredictorSpec)
+	if in.Ensemble != nil {
+		in, out := &in.Ensemble, &out.Ensemble
+		*out = new(EnsembleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]PowerConsumptionPredictorSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerConsumptionPredictor.
@@ -198,3 +486,141 @@ func (in *PowerConsumptionPredictor) DeepCopy() *PowerConsumptionPredictor {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerConsumptionPredictorSpec) DeepCopyInto(out *PowerConsumptionPredictorSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HTTPClient != nil {
+		in, out := &in.HTTPClient, &out.HTTPClient
+		*out = new(HTTPClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ErrorBounds != nil {
+		in, out := &in.ErrorBounds, &out.ErrorBounds
+		*out = new(ErrorBoundsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MLServer != nil {
+		in, out := &in.MLServer, &out.MLServer
+		*out = new(MLServerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TFServing != nil {
+		in, out := &in.TFServing, &out.TFServing
+		*out = new(TFServingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerCurve != nil {
+		in, out := &in.PowerCurve, &out.PowerCurve
+		*out = new(PowerCurveConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Polynomial != nil {
+		in, out := &in.Polynomial, &out.Polynomial
+		*out = new(PolynomialConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerConsumptionPredictorSpec.
+func (in *PowerConsumptionPredictorSpec) DeepCopy() *PowerConsumptionPredictorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerConsumptionPredictorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerCurveConfig) DeepCopyInto(out *PowerCurveConfig) {
+	*out = *in
+	if in.Points != nil {
+		in, out := &in.Points, &out.Points
+		*out = make([]PowerCurvePoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AmbientTempCorrection != nil {
+		in, out := &in.AmbientTempCorrection, &out.AmbientTempCorrection
+		*out = new(AmbientTempCorrection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerCurveConfig.
+func (in *PowerCurveConfig) DeepCopy() *PowerCurveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerCurveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerCurvePoint) DeepCopyInto(out *PowerCurvePoint) {
+	*out = *in
+	out.Watts = in.Watts.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerCurvePoint.
+func (in *PowerCurvePoint) DeepCopy() *PowerCurvePoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerCurvePoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictionCacheConfig) DeepCopyInto(out *PredictionCacheConfig) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictionCacheConfig.
+func (in *PredictionCacheConfig) DeepCopy() *PredictionCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictionCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TFServingConfig) DeepCopyInto(out *TFServingConfig) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFServingConfig.
+func (in *TFServingConfig) DeepCopy() *TFServingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TFServingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
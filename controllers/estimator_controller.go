@@ -6,6 +6,7 @@ import (
 	"math"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 	corev1 "k8s.io/api/core/v1"
@@ -23,6 +24,9 @@ import (
 type EstimatorReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// APIReader is an uncached client used by NodeMonitors that read resources not supporting watch (e.g. metrics.k8s.io),
+	// Client is used if nil.
+	APIReader client.Reader
 
 	estimators *estimator.Estimators
 }
@@ -56,6 +60,10 @@ func (r *EstimatorReconciler) startEstimatorServer() error {
 //+kubebuilder:rbac:groups=waofed.bitmedia.co.jp,resources=estimators/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=waofed.bitmedia.co.jp,resources=estimators/finalizers,verbs=update
 //+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metrics.k8s.io,resources=nodes,verbs=get;list
 
 // Reconcile moves the current state of the cluster closer to the desired state.
 func (r *EstimatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -82,8 +90,15 @@ func (r *EstimatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	// setup the prediction cache, the previous one is reused to keep its statistics
+	var prevCache *estimator.PCPredictionCache
+	if prev, ok := r.estimators.Get(req.String()); ok {
+		prevCache = prev.PredictionCache
+	}
+	cache := newPCPredictionCache(ctx, estConf.Spec.PredictionCache, prevCache)
+
 	// setup estimator.Node
-	estNodeList, err := r.reconcileEstimatorNodes(ctx, &estConf)
+	estNodeList, err := r.reconcileEstimatorNodes(ctx, &estConf, cache)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -97,7 +112,7 @@ func (r *EstimatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			lg.Error(err, "duplicate node name found")
 		}
 	}
-	e := &estimator.Estimator{Nodes: estNodes}
+	e := &estimator.Estimator{Nodes: estNodes, PredictionCache: cache}
 	r.estimators.Delete(req.String())
 	if ok := r.estimators.Add(req.String(), e); !ok {
 		err := fmt.Errorf("r.estimators.Add() returned false: %s", req.String())
@@ -108,7 +123,7 @@ func (r *EstimatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	return ctrl.Result{}, nil
 }
 
-func (r *EstimatorReconciler) reconcileEstimatorNodes(ctx context.Context, estConf *v1beta1.Estimator) ([]*estimator.Node, error) {
+func (r *EstimatorReconciler) reconcileEstimatorNodes(ctx context.Context, estConf *v1beta1.Estimator, cache *estimator.PCPredictionCache) ([]*estimator.Node, error) {
 	lg := log.FromContext(ctx)
 	lg.Info("reconcileEstimatorNodes")
 
@@ -136,16 +151,44 @@ func (r *EstimatorReconciler) reconcileEstimatorNodes(ctx context.Context, estCo
 				nm = &estimator.FakeNodeMonitor{FetchFunc: func(ctx context.Context, base *estimator.NodeStatus) error { return nil }}
 			case v1beta1.NodeMonitorTypeFake:
 				nm = setupFakeNodeMonitor(r.Client, client.ObjectKeyFromObject(&node))
+			case v1beta1.NodeMonitorTypeMetricsAPI:
+				nm = &estimator.MetricsAPINodeMonitor{Client: r.apiReader(), NodeName: name}
 			case v1beta1.NodeMonitorTypeDifferentialPressureAPI:
-				var err error
-				nm, err = estimator.NewDifferentialPressureNodeMonitorFromURL(nma.Endpoint)
+				v, err := estimator.NewDifferentialPressureNodeMonitorFromURL(nma.Endpoint)
 				if err != nil {
 					lg.Error(err, fmt.Sprintf("node=%v NodeMonitorType=%v could not initialize: %v", name, nmType, err))
+					break
 				}
+				v.HTTPClient = newHTTPClient(nma.HTTPClient)
+				nm = v
 			case v1beta1.NodeMonitorTypeIPMIExporter:
-				lg.Info(fmt.Sprintf("NodeMonitorType=%v is not implemented", nmType))
+				var inletTempSensor string
+				if nma.IPMIExporter != nil {
+					inletTempSensor = nma.IPMIExporter.InletTempSensor
+				}
+				v, err := estimator.NewIPMIExporterNodeMonitorFromURL(nma.Endpoint, inletTempSensor)
+				if err != nil {
+					lg.Error(err, fmt.Sprintf("node=%v NodeMonitorType=%v could not initialize: %v", name, nmType, err))
+					break
+				}
+				v.HTTPClient = newHTTPClient(nma.HTTPClient)
+				nm = v
 			case v1beta1.NodeMonitorTypeRedfish:
-				lg.Info(fmt.Sprintf("NodeMonitorType=%v is not implemented", nmType))
+				v, err := estimator.NewRedfishNodeMonitorFromURL(nma.Endpoint)
+				if err != nil {
+					lg.Error(err, fmt.Sprintf("node=%v NodeMonitorType=%v could not initialize: %v", name, nmType, err))
+					break
+				}
+				v.InsecureSkipVerify = nma.InsecureSkipVerify
+				v.HTTPClient = newHTTPClient(nma.HTTPClient)
+				if nma.BasicAuthSecret != nil {
+					v.Username, v.Password, err = r.getBasicAuth(ctx, estConf.Namespace, nma.BasicAuthSecret.Name)
+					if err != nil {
+						lg.Error(err, fmt.Sprintf("node=%v NodeMonitorType=%v could not get basic auth: %v", name, nmType, err))
+						break
+					}
+				}
+				nm = v
 			default:
 				lg.Info(fmt.Sprintf("NodeMonitorType=%v is not defined", nmType))
 			}
@@ -154,32 +197,368 @@ func (r *EstimatorReconciler) reconcileEstimatorNodes(ctx context.Context, estCo
 		}
 
 		// PowerConsumptionPredictor
+		pcpConfig := nodeConfig.PowerConsumptionPredictor
 		var pcp estimator.PowerConsumptionPredictor
-		pcpType := v1beta1.PowerConsumptionPredictorType(nodeConfig.PowerConsumptionPredictor.Type)
-		switch pcpType {
-		case v1beta1.PowerConsumptionPredictorTypeNone:
-			// return +Inf to suppress warnings
-			// NOTE: Estimator fills failed predictions with +Inf so this only suppresses warnings.
-			pcp = &estimator.FakePCPredictor{PredictFunc: func(context.Context, int, *estimator.NodeStatus) (float64, error) {
-				return math.Inf(1), nil
-			}}
-		case v1beta1.PowerConsumptionPredictorTypeFake:
-			pcp = setupFakePCPredictor(r.Client, client.ObjectKeyFromObject(&node))
-		case v1beta1.PowerConsumptionPredictorTypeMLServer:
-			v, err := estimator.NewMLServerPCPredictorFromURL(nodeConfig.PowerConsumptionPredictor.Endpoint)
-			if err != nil {
-				lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v wrong endpoint url specified: %v", name, pcpType, err))
-			} else {
-				pcp = v
-			}
-		default:
-			lg.Info(fmt.Sprintf("PowerConsumptionPredictorType=%v is not defined", pcpType))
+		if len(pcpConfig.Fallbacks) == 0 && pcpConfig.Timeout == nil {
+			pcp = r.newPrimaryPCPredictor(ctx, estConf, &node, pcpConfig)
+		} else {
+			pcp = r.newFallbackPCPredictor(ctx, estConf, &node, pcpConfig)
 		}
-		lg.Info(fmt.Sprintf("node=%v powerConsumptionPredictor.Type=%v pcp=%+v", name, pcpType, pcp))
+		if pcp != nil && cache != nil {
+			pcp = &estimator.CachedPCPredictor{NodeName: name, Predictor: pcp, Cache: cache}
+		}
+		lg.Info(fmt.Sprintf("node=%v powerConsumptionPredictor.Type=%v pcp=%+v", name, pcpConfig.Type, pcp))
 
 		estNode := estimator.NewNode(name, nms, nodeConfig.NodeMonitor.RefreshInterval.Duration, pcp)
+		if len(nodeConfig.NodeMonitor.MaxAge) != 0 {
+			estNode.NodeStatusMaxAges = map[estimator.NodeStatusKey]time.Duration{}
+			for k, v := range nodeConfig.NodeMonitor.MaxAge {
+				estNode.NodeStatusMaxAges[estimator.NodeStatusKey(k)] = v.Duration
+			}
+		}
+		if nodeConfig.NodeMonitor.HistorySize != nil {
+			estNode.NodeStatusHistorySize = *nodeConfig.NodeMonitor.HistorySize
+		}
+		estNode.NodeStatusAggregations = newNodeStatusAggregations(ctx, name, nodeConfig.NodeMonitor.Aggregation)
 		estNodeList = append(estNodeList, estNode)
 	}
 
 	return estNodeList, nil
 }
+
+// newNodeStatusAggregations returns the aggregations of the node, invalid ones are logged and skipped.
+func newNodeStatusAggregations(ctx context.Context, nodeName string, cfg map[string]v1beta1.NodeStatusAggregation) map[estimator.NodeStatusKey]estimator.NodeStatusAggregation {
+	lg := log.FromContext(ctx)
+
+	if len(cfg) == 0 {
+		return nil
+	}
+	aggs := map[estimator.NodeStatusKey]estimator.NodeStatusAggregation{}
+	for k, c := range cfg {
+		window := 0
+		if c.Window != nil {
+			window = *c.Window
+		}
+		alpha := 0.0
+		if c.Alpha != nil {
+			alpha = c.Alpha.AsApproximateFloat64()
+		}
+		agg, err := estimator.NewNodeStatusAggregation(estimator.NodeStatusAggregationMethod(c.Method), window, alpha)
+		if err != nil {
+			lg.Error(err, fmt.Sprintf("node=%v nodeMonitor.aggregation[%v] could not initialize: %v", nodeName, k, err))
+			continue
+		}
+		aggs[estimator.NodeStatusKey(k)] = agg
+	}
+	return aggs
+}
+
+// newPCPredictor returns the PowerConsumptionPredictor for the node, errors are logged and nil is returned.
+func (r *EstimatorReconciler) newPCPredictor(ctx context.Context, estConf *v1beta1.Estimator, node *corev1.Node, spec *v1beta1.PowerConsumptionPredictorSpec) estimator.PowerConsumptionPredictor {
+	lg := log.FromContext(ctx)
+
+	var pcp estimator.PowerConsumptionPredictor
+	pcpType := v1beta1.PowerConsumptionPredictorType(spec.Type)
+	switch pcpType {
+	case v1beta1.PowerConsumptionPredictorTypeNone:
+		// return +Inf to suppress warnings
+		// NOTE: Estimator fills failed predictions with +Inf so this only suppresses warnings.
+		pcp = &estimator.FakePCPredictor{PredictFunc: func(context.Context, int, *estimator.NodeStatus) (float64, error) {
+			return math.Inf(1), nil
+		}}
+	case v1beta1.PowerConsumptionPredictorTypeFake:
+		pcp = setupFakePCPredictor(r.Client, client.ObjectKeyFromObject(node))
+	case v1beta1.PowerConsumptionPredictorTypeMLServer:
+		v, err := estimator.NewMLServerPCPredictorFromURL(spec.Endpoint)
+		if err != nil {
+			lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v wrong endpoint url specified: %v", node.Name, pcpType, err))
+		} else {
+			v.V2TensorSpec = newV2TensorSpec(spec.MLServer)
+			v.HTTPClient = newHTTPClient(spec.HTTPClient)
+			pcp = v
+		}
+	case v1beta1.PowerConsumptionPredictorTypeMLServerGRPC:
+		v, err := estimator.NewMLServerGRPCPCPredictorFromURL(spec.Endpoint)
+		if err != nil {
+			lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v wrong endpoint url specified: %v", node.Name, pcpType, err))
+		} else {
+			v.V2TensorSpec = newV2TensorSpec(spec.MLServer)
+			pcp = v
+		}
+	case v1beta1.PowerConsumptionPredictorTypeTFServing:
+		v, err := estimator.NewTFServingPCPredictorFromURL(spec.Endpoint)
+		if err != nil {
+			lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v wrong endpoint url specified: %v", node.Name, pcpType, err))
+		} else {
+			if cfg := spec.TFServing; cfg != nil {
+				for _, k := range cfg.Features {
+					v.Features = append(v.Features, estimator.NodeStatusKey(k))
+				}
+				v.InputName = cfg.InputName
+				v.SignatureName = cfg.SignatureName
+				v.OutputIndex = cfg.OutputIndex
+			}
+			v.HTTPClient = newHTTPClient(spec.HTTPClient)
+			pcp = v
+		}
+	case v1beta1.PowerConsumptionPredictorTypePowerCurve:
+		v, err := r.newPowerCurvePCPredictor(ctx, estConf.Namespace, spec.PowerCurve)
+		if err != nil {
+			lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v could not initialize: %v", node.Name, pcpType, err))
+			break
+		}
+		pcp = v
+	case v1beta1.PowerConsumptionPredictorTypePolynomial:
+		v, err := newPolynomialPCPredictor(spec.Polynomial)
+		if err != nil {
+			lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v could not initialize: %v", node.Name, pcpType, err))
+			break
+		}
+		pcp = v
+	case v1beta1.PowerConsumptionPredictorTypeEnsemble:
+		lg.Info(fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v is only supported as the primary predictor", node.Name, pcpType))
+	default:
+		lg.Info(fmt.Sprintf("PowerConsumptionPredictorType=%v is not defined", pcpType))
+	}
+	if pcp != nil && spec.ErrorBounds != nil {
+		v, err := newErrorBoundsPCPredictor(pcp, spec.ErrorBounds)
+		if err != nil {
+			lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v could not initialize: %v", node.Name, pcpType, err))
+			return nil
+		}
+		pcp = v
+	}
+	return pcp
+}
+
+// newPrimaryPCPredictor is newPCPredictor that also supports Type Ensemble.
+func (r *EstimatorReconciler) newPrimaryPCPredictor(ctx context.Context, estConf *v1beta1.Estimator, node *corev1.Node, cfg *v1beta1.PowerConsumptionPredictor) estimator.PowerConsumptionPredictor {
+	lg := log.FromContext(ctx)
+
+	if cfg.Type != v1beta1.PowerConsumptionPredictorTypeEnsemble {
+		return r.newPCPredictor(ctx, estConf, node, &cfg.PowerConsumptionPredictorSpec)
+	}
+	v, err := r.newEnsemblePCPredictor(ctx, estConf, node, cfg.Ensemble)
+	if err != nil {
+		lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v could not initialize: %v", node.Name, cfg.Type, err))
+		return nil
+	}
+	if cfg.ErrorBounds == nil {
+		return v
+	}
+	eb, err := newErrorBoundsPCPredictor(v, cfg.ErrorBounds)
+	if err != nil {
+		lg.Error(err, fmt.Sprintf("node=%v PowerConsumptionPredictorType=%v could not initialize: %v", node.Name, cfg.Type, err))
+		return nil
+	}
+	return eb
+}
+
+func newErrorBoundsPCPredictor(pcp estimator.PowerConsumptionPredictor, cfg *v1beta1.ErrorBoundsConfig) (*estimator.ErrorBoundsPCPredictor, error) {
+	return estimator.NewErrorBoundsPCPredictor(pcp, cfg.Absolute.AsApproximateFloat64(), cfg.Relative.AsApproximateFloat64())
+}
+
+// newEnsemblePCPredictor returns an EnsemblePCPredictor of the members,
+// members that could not be initialized are skipped.
+func (r *EstimatorReconciler) newEnsemblePCPredictor(ctx context.Context, estConf *v1beta1.Estimator, node *corev1.Node, cfg *v1beta1.EnsembleConfig) (*estimator.EnsemblePCPredictor, error) {
+	lg := log.FromContext(ctx)
+
+	if cfg == nil {
+		return nil, fmt.Errorf("ensemble is not specified")
+	}
+	var members []estimator.EnsemblePCPredictorMember
+	for i := range cfg.Members {
+		spec := &cfg.Members[i]
+		pcp := r.newPCPredictor(ctx, estConf, node, &spec.PowerConsumptionPredictorSpec)
+		if pcp == nil {
+			lg.Info(fmt.Sprintf("node=%v ensemble.Members[%d].Type=%v is skipped", node.Name, i, spec.Type))
+			continue
+		}
+		m := estimator.EnsemblePCPredictorMember{Name: spec.Name, Predictor: pcp, Weight: 1}
+		if m.Name == "" {
+			m.Name = string(spec.Type)
+		}
+		if spec.Weight != nil {
+			m.Weight = spec.Weight.AsApproximateFloat64()
+		}
+		if spec.Timeout != nil {
+			m.Timeout = spec.Timeout.Duration
+		}
+		members = append(members, m)
+	}
+	return estimator.NewEnsemblePCPredictor(estimator.EnsembleStrategy(cfg.Strategy), members)
+}
+
+// newFallbackPCPredictor returns a FallbackPCPredictor that tries the predictor and its fallbacks in order,
+// predictors that could not be initialized are skipped.
+func (r *EstimatorReconciler) newFallbackPCPredictor(ctx context.Context, estConf *v1beta1.Estimator, node *corev1.Node, cfg *v1beta1.PowerConsumptionPredictor) estimator.PowerConsumptionPredictor {
+	lg := log.FromContext(ctx)
+
+	specs := append([]v1beta1.PowerConsumptionPredictorSpec{cfg.PowerConsumptionPredictorSpec}, cfg.Fallbacks...)
+	var items []estimator.FallbackPCPredictorItem
+	for i := range specs {
+		spec := &specs[i]
+		var pcp estimator.PowerConsumptionPredictor
+		if i == 0 {
+			pcp = r.newPrimaryPCPredictor(ctx, estConf, node, cfg)
+		} else {
+			pcp = r.newPCPredictor(ctx, estConf, node, spec)
+		}
+		if pcp == nil {
+			lg.Info(fmt.Sprintf("node=%v powerConsumptionPredictor[%d].Type=%v is skipped", node.Name, i, spec.Type))
+			continue
+		}
+		item := estimator.FallbackPCPredictorItem{Name: spec.Name, Predictor: pcp}
+		if item.Name == "" {
+			item.Name = string(spec.Type)
+		}
+		if spec.Timeout != nil {
+			item.Timeout = spec.Timeout.Duration
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return &estimator.FallbackPCPredictor{Predictors: items}
+}
+
+// newHTTPClient returns nil (http.DefaultClient without retries) if cfg is nil.
+func newHTTPClient(cfg *v1beta1.HTTPClientConfig) *estimator.HTTPClient {
+	if cfg == nil {
+		return nil
+	}
+	c := &estimator.HTTPClient{MaxRetries: cfg.MaxRetries}
+	if cfg.Timeout != nil {
+		c.Timeout = cfg.Timeout.Duration
+	}
+	if cfg.RetryBackoff != nil {
+		c.RetryBackoff = cfg.RetryBackoff.Duration
+	}
+	if cb := cfg.CircuitBreaker; cb != nil {
+		c.CircuitBreaker = &estimator.CircuitBreaker{FailureThreshold: cb.FailureThreshold}
+		if cb.OpenDuration != nil {
+			c.CircuitBreaker.OpenDuration = cb.OpenDuration.Duration
+		}
+	}
+	return c
+}
+
+// newPCPredictionCache returns nil if cfg is nil.
+// prev is reused if not nil so that the statistics cover the lifetime of the Estimator,
+// its entries are dropped as the predictors may have changed.
+func newPCPredictionCache(ctx context.Context, cfg *v1beta1.PredictionCacheConfig, prev *estimator.PCPredictionCache) *estimator.PCPredictionCache {
+	if cfg == nil {
+		return nil
+	}
+	var ttl time.Duration
+	if cfg.TTL != nil {
+		ttl = cfg.TTL.Duration
+	}
+	if prev == nil {
+		return estimator.NewPCPredictionCache(cfg.Size, ttl)
+	}
+	stats := prev.Stats()
+	log.FromContext(ctx).Info(fmt.Sprintf("predictionCache hits=%d misses=%d len=%d", stats.Hits, stats.Misses, stats.Len))
+	prev.Reset(cfg.Size, ttl)
+	return prev
+}
+
+// newV2TensorSpec returns the default spec if cfg is nil.
+func newV2TensorSpec(cfg *v1beta1.MLServerConfig) estimator.V2TensorSpec {
+	var spec estimator.V2TensorSpec
+	if cfg == nil {
+		return spec
+	}
+	for _, k := range cfg.Features {
+		spec.Features = append(spec.Features, estimator.NodeStatusKey(k))
+	}
+	spec.InputName = cfg.InputName
+	spec.Datatype = cfg.Datatype
+	spec.OutputIndex = cfg.OutputIndex
+	return spec
+}
+
+func (r *EstimatorReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// getBasicAuth returns "username" and "password" in the given Secret.
+func (r *EstimatorReconciler) getBasicAuth(ctx context.Context, namespace, secretName string) (username, password string, err error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, &secret); err != nil {
+		return "", "", err
+	}
+	u, ok := secret.Data[corev1.BasicAuthUsernameKey]
+	if !ok {
+		return "", "", fmt.Errorf("key %s not found in Secret %s/%s", corev1.BasicAuthUsernameKey, namespace, secretName)
+	}
+	p, ok := secret.Data[corev1.BasicAuthPasswordKey]
+	if !ok {
+		return "", "", fmt.Errorf("key %s not found in Secret %s/%s", corev1.BasicAuthPasswordKey, namespace, secretName)
+	}
+	return string(u), string(p), nil
+}
+
+// newPowerCurvePCPredictor returns a PowerCurvePCPredictor with the inline points or the points in the ConfigMap.
+func (r *EstimatorReconciler) newPowerCurvePCPredictor(ctx context.Context, namespace string, cfg *v1beta1.PowerCurveConfig) (*estimator.PowerCurvePCPredictor, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("powerCurve must be specified")
+	}
+	var points []estimator.PowerCurvePoint
+	switch {
+	case len(cfg.Points) != 0:
+		// cpuUsage of the points is in percent
+		for _, p := range cfg.Points {
+			points = append(points, estimator.PowerCurvePoint{
+				CPUUsage: float64(p.CPUUsage) / 100,
+				Watts:    p.Watts.AsApproximateFloat64(),
+			})
+		}
+	case cfg.ConfigMapKeyRef != nil:
+		var cm corev1.ConfigMap
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cfg.ConfigMapKeyRef.Name}, &cm); err != nil {
+			return nil, err
+		}
+		v, ok := cm.Data[cfg.ConfigMapKeyRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in ConfigMap %s/%s", cfg.ConfigMapKeyRef.Key, namespace, cfg.ConfigMapKeyRef.Name)
+		}
+		var err error
+		points, err = estimator.ParsePowerCurve(v)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("points or configMapKeyRef must be specified")
+	}
+	var atc *estimator.AmbientTempCorrection
+	if cfg.AmbientTempCorrection != nil {
+		atc = &estimator.AmbientTempCorrection{
+			ReferenceTemp:  cfg.AmbientTempCorrection.ReferenceTemp.AsApproximateFloat64(),
+			WattsPerDegree: cfg.AmbientTempCorrection.WattsPerDegree.AsApproximateFloat64(),
+		}
+	}
+	return estimator.NewPowerCurvePCPredictor(points, atc)
+}
+
+// newPolynomialPCPredictor converts the coefficients to float64.
+func newPolynomialPCPredictor(cfg *v1beta1.PolynomialConfig) (*estimator.PolynomialPCPredictor, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("polynomial must be specified")
+	}
+	terms := make([]estimator.PolynomialTerm, len(cfg.Terms))
+	for i, t := range cfg.Terms {
+		terms[i] = estimator.PolynomialTerm{
+			Coefficient: t.Coefficient.AsApproximateFloat64(),
+			Powers:      make(map[estimator.NodeStatusKey]int, len(t.Powers)),
+		}
+		for k, pow := range t.Powers {
+			terms[i].Powers[estimator.NodeStatusKey(k)] = pow
+		}
+	}
+	return estimator.NewPolynomialPCPredictor(cfg.Intercept.AsApproximateFloat64(), terms)
+}
@@ -0,0 +1,296 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1beta1 "github.com/Nedopro2022/wao-estimator/api/v1beta1"
+	"github.com/Nedopro2022/wao-estimator/pkg/estimator"
+)
+
+func TestEstimatorReconciler_newPowerCurvePCPredictor(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "power-curves"},
+		Data:       map[string]string{"node0": "cpuUsage,watts\n0,50\n100,200.5\n"},
+	}
+	r := &EstimatorReconciler{Client: fake.NewClientBuilder().WithObjects(cm).Build()}
+
+	tests := []struct {
+		name    string
+		cfg     *v1beta1.PowerCurveConfig
+		want    *estimator.PowerCurvePCPredictor
+		wantErr bool
+	}{
+		{"inline", &v1beta1.PowerCurveConfig{
+			Points: []v1beta1.PowerCurvePoint{
+				{CPUUsage: 100, Watts: resource.MustParse("200.5")},
+				{CPUUsage: 0, Watts: resource.MustParse("50")},
+			},
+			AmbientTempCorrection: &v1beta1.AmbientTempCorrection{
+				ReferenceTemp:  resource.MustParse("25"),
+				WattsPerDegree: resource.MustParse("0.5"),
+			},
+		}, &estimator.PowerCurvePCPredictor{
+			Points:                []estimator.PowerCurvePoint{{CPUUsage: 0, Watts: 50}, {CPUUsage: 1, Watts: 200.5}},
+			AmbientTempCorrection: &estimator.AmbientTempCorrection{ReferenceTemp: 25, WattsPerDegree: 0.5},
+		}, false},
+		{"configmap", &v1beta1.PowerCurveConfig{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "power-curves"},
+				Key:                  "node0",
+			},
+		}, &estimator.PowerCurvePCPredictor{
+			Points: []estimator.PowerCurvePoint{{CPUUsage: 0, Watts: 50}, {CPUUsage: 1, Watts: 200.5}},
+		}, false},
+		{"configmap_key_not_found", &v1beta1.PowerCurveConfig{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "power-curves"},
+				Key:                  "node1",
+			},
+		}, nil, true},
+		{"configmap_not_found", &v1beta1.PowerCurveConfig{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "hoge"},
+				Key:                  "node0",
+			},
+		}, nil, true},
+		{"empty", &v1beta1.PowerCurveConfig{}, nil, true},
+		{"nil", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.newPowerCurvePCPredictor(context.Background(), "default", tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EstimatorReconciler.newPowerCurvePCPredictor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EstimatorReconciler.newPowerCurvePCPredictor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newPolynomialPCPredictor(t *testing.T) {
+	p, err := newPolynomialPCPredictor(&v1beta1.PolynomialConfig{
+		Intercept: resource.MustParse("40.5"),
+		Terms: []v1beta1.PolynomialTerm{
+			{Coefficient: resource.MustParse("2"), Powers: map[string]int{"cpuUsage": 1}},
+			{Coefficient: resource.MustParse("-0.5"), Powers: map[string]int{"ambientTemp": 1}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newPolynomialPCPredictor() error = %v", err)
+	}
+	status := estimator.NewNodeStatus()
+	status.SetFloat(estimator.NodeStatusCPUUsage, 10)
+	status.SetFloat(estimator.NodeStatusAmbientTemp, 20)
+	status.SetInt(estimator.NodeStatusLogicalProcessors, 4)
+	// 40.5 + 2*(10+1000/4000) - 0.5*20
+	got, err := p.Predict(context.Background(), 1000, status)
+	if err != nil || got != 51 {
+		t.Errorf("PolynomialPCPredictor.Predict() = %v, %v, want 51", got, err)
+	}
+
+	if _, err := newPolynomialPCPredictor(nil); err == nil {
+		t.Errorf("newPolynomialPCPredictor(nil) error = nil")
+	}
+}
+
+func TestEstimatorReconciler_newFallbackPCPredictor(t *testing.T) {
+	r := &EstimatorReconciler{Client: fake.NewClientBuilder().Build()}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node0"}}
+	estConf := &v1beta1.Estimator{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "default"}}
+
+	cfg := &v1beta1.PowerConsumptionPredictor{
+		PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+			Type:     v1beta1.PowerConsumptionPredictorTypeMLServer,
+			Endpoint: "http://localhost:8080/v2/models/model1/versions/v0.1.0",
+			Timeout:  &metav1.Duration{Duration: time.Second},
+		},
+		Fallbacks: []v1beta1.PowerConsumptionPredictorSpec{
+			{
+				// skipped
+				Type:     v1beta1.PowerConsumptionPredictorTypeTFServing,
+				Endpoint: "hoge",
+			},
+			{
+				Type: v1beta1.PowerConsumptionPredictorTypePolynomial,
+				Name: "linear",
+				Polynomial: &v1beta1.PolynomialConfig{
+					Intercept: resource.MustParse("50"),
+				},
+			},
+		},
+	}
+	got, ok := r.newFallbackPCPredictor(context.Background(), estConf, node, cfg).(*estimator.FallbackPCPredictor)
+	if !ok {
+		t.Fatalf("EstimatorReconciler.newFallbackPCPredictor() is not a *FallbackPCPredictor")
+	}
+	if len(got.Predictors) != 2 {
+		t.Fatalf("EstimatorReconciler.newFallbackPCPredictor() has %d predictors, want 2", len(got.Predictors))
+	}
+	if p := got.Predictors[0]; p.Name != "MLServer" || p.Timeout != time.Second {
+		t.Errorf("EstimatorReconciler.newFallbackPCPredictor().Predictors[0] = %+v", p)
+	}
+	if p := got.Predictors[1]; p.Name != "linear" || p.Timeout != 0 {
+		t.Errorf("EstimatorReconciler.newFallbackPCPredictor().Predictors[1] = %+v", p)
+	}
+
+	cfg.PowerConsumptionPredictorSpec = v1beta1.PowerConsumptionPredictorSpec{Type: v1beta1.PowerConsumptionPredictorTypeMLServer, Endpoint: "hoge"}
+	cfg.Fallbacks = nil
+	if got := r.newFallbackPCPredictor(context.Background(), estConf, node, cfg); got != nil {
+		t.Errorf("EstimatorReconciler.newFallbackPCPredictor() = %+v, want nil", got)
+	}
+}
+
+func TestEstimatorReconciler_newPrimaryPCPredictor_ensemble(t *testing.T) {
+	r := &EstimatorReconciler{Client: fake.NewClientBuilder().Build()}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node0"}}
+	estConf := &v1beta1.Estimator{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "default"}}
+	weight := resource.MustParse("0.1")
+
+	cfg := &v1beta1.PowerConsumptionPredictor{
+		PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+			Type: v1beta1.PowerConsumptionPredictorTypeEnsemble,
+		},
+		Ensemble: &v1beta1.EnsembleConfig{
+			Strategy: v1beta1.EnsembleStrategyWeightedMean,
+			Members: []v1beta1.EnsembleMember{
+				{PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+					Type:     v1beta1.PowerConsumptionPredictorTypeMLServer,
+					Endpoint: "http://localhost:8080/v2/models/model1/versions/v0.1.0",
+				}},
+				{PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+					Type:     v1beta1.PowerConsumptionPredictorTypeMLServer,
+					Endpoint: "http://localhost:8080/v2/models/model1/versions/v0.2.0",
+					Name:     "canary",
+					Timeout:  &metav1.Duration{Duration: time.Second},
+				}, Weight: &weight},
+				{PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+					// skipped
+					Type: v1beta1.PowerConsumptionPredictorTypeEnsemble,
+				}},
+			},
+		},
+	}
+	got, ok := r.newPrimaryPCPredictor(context.Background(), estConf, node, cfg).(*estimator.EnsemblePCPredictor)
+	if !ok {
+		t.Fatalf("EstimatorReconciler.newPrimaryPCPredictor() is not a *EnsemblePCPredictor")
+	}
+	if got.Strategy != estimator.EnsembleStrategyWeightedMean || len(got.Members) != 2 {
+		t.Fatalf("EstimatorReconciler.newPrimaryPCPredictor() = %+v", got)
+	}
+	if m := got.Members[0]; m.Name != "MLServer" || m.Weight != 1 || m.Timeout != 0 {
+		t.Errorf("EstimatorReconciler.newPrimaryPCPredictor().Members[0] = %+v", m)
+	}
+	if m := got.Members[1]; m.Name != "canary" || m.Weight != 0.1 || m.Timeout != time.Second {
+		t.Errorf("EstimatorReconciler.newPrimaryPCPredictor().Members[1] = %+v", m)
+	}
+
+	cfg.Ensemble = nil
+	if got := r.newPrimaryPCPredictor(context.Background(), estConf, node, cfg); got != nil {
+		t.Errorf("EstimatorReconciler.newPrimaryPCPredictor() = %+v, want nil", got)
+	}
+}
+
+func TestEstimatorReconciler_newPCPredictor_errorBounds(t *testing.T) {
+	r := &EstimatorReconciler{Client: fake.NewClientBuilder().Build()}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node0"}}
+	estConf := &v1beta1.Estimator{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "default"}}
+
+	spec := &v1beta1.PowerConsumptionPredictorSpec{
+		Type:     v1beta1.PowerConsumptionPredictorTypeMLServer,
+		Endpoint: "http://localhost:8080/v2/models/model1/versions/v0.1.0",
+		ErrorBounds: &v1beta1.ErrorBoundsConfig{
+			Absolute: resource.MustParse("5"),
+			Relative: resource.MustParse("0.05"),
+		},
+	}
+	got, ok := r.newPCPredictor(context.Background(), estConf, node, spec).(*estimator.ErrorBoundsPCPredictor)
+	if !ok {
+		t.Fatalf("EstimatorReconciler.newPCPredictor() is not a *ErrorBoundsPCPredictor")
+	}
+	if _, ok := got.Predictor.(*estimator.MLServerPCPredictor); !ok || got.AbsoluteError != 5 || got.RelativeError != 0.05 {
+		t.Errorf("EstimatorReconciler.newPCPredictor() = %+v", got)
+	}
+
+	spec.ErrorBounds.Absolute = resource.MustParse("-1")
+	if got := r.newPCPredictor(context.Background(), estConf, node, spec); got != nil {
+		t.Errorf("EstimatorReconciler.newPCPredictor() = %+v, want nil", got)
+	}
+}
+
+func Test_newNodeStatusAggregations(t *testing.T) {
+	window := 5
+	alpha := resource.MustParse("0.2")
+	cfg := map[string]v1beta1.NodeStatusAggregation{
+		"staticPressureDiff": {Method: v1beta1.NodeStatusAggregationMedian, Window: &window},
+		"cpuUsage":           {Method: v1beta1.NodeStatusAggregationEWMA, Alpha: &alpha},
+		"ambientTemp":        {Method: "Mean"}, // invalid
+	}
+	want := map[estimator.NodeStatusKey]estimator.NodeStatusAggregation{
+		estimator.NodeStatusStaticPressureDiff: {Method: estimator.NodeStatusAggregationMedian, Window: 5, Alpha: estimator.NodeStatusAggregationDefaultAlpha},
+		estimator.NodeStatusCPUUsage:           {Method: estimator.NodeStatusAggregationEWMA, Alpha: 0.2},
+	}
+	if got := newNodeStatusAggregations(context.Background(), "node0", cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("newNodeStatusAggregations() = %+v, want %+v", got, want)
+	}
+	if got := newNodeStatusAggregations(context.Background(), "node0", nil); got != nil {
+		t.Errorf("newNodeStatusAggregations() = %+v, want nil", got)
+	}
+}
+
+func Test_newHTTPClient(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *v1beta1.HTTPClientConfig
+		want *estimator.HTTPClient
+	}{
+		{"nil", nil, nil},
+		{"empty", &v1beta1.HTTPClientConfig{}, &estimator.HTTPClient{}},
+		{"all", &v1beta1.HTTPClientConfig{
+			Timeout:      &metav1.Duration{Duration: time.Second},
+			MaxRetries:   3,
+			RetryBackoff: &metav1.Duration{Duration: 200 * time.Millisecond},
+			CircuitBreaker: &v1beta1.CircuitBreakerConfig{
+				FailureThreshold: 10,
+				OpenDuration:     &metav1.Duration{Duration: time.Minute},
+			},
+		}, &estimator.HTTPClient{
+			Timeout:        time.Second,
+			MaxRetries:     3,
+			RetryBackoff:   200 * time.Millisecond,
+			CircuitBreaker: &estimator.CircuitBreaker{FailureThreshold: 10, OpenDuration: time.Minute},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newHTTPClient(tt.cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newHTTPClient() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newPCPredictionCache(t *testing.T) {
+	if got := newPCPredictionCache(context.Background(), nil, estimator.NewPCPredictionCache(0, 0)); got != nil {
+		t.Errorf("newPCPredictionCache() = %v, want nil", got)
+	}
+	cfg := &v1beta1.PredictionCacheConfig{Size: 10}
+	prev := newPCPredictionCache(context.Background(), cfg, nil)
+	if prev == nil {
+		t.Fatalf("newPCPredictionCache() = nil")
+	}
+	// the previous cache is reused to keep the statistics
+	if got := newPCPredictionCache(context.Background(), cfg, prev); got != prev {
+		t.Errorf("newPCPredictionCache() = %p, want %p", got, prev)
+	}
+}
@@ -39,17 +39,23 @@ func setupFakeNodeMonitor(k8sClient client.Client, nodeObjKey client.ObjectKey)
 
 		cu, err := labelValueFloat(node.Labels, labelNodeStatusCPUUsage)
 		if err == nil {
-			estimator.NodeStatusSetCPUUsage(base, cu)
+			if err := base.SetFloat(estimator.NodeStatusCPUUsage, cu); err != nil {
+				return err
+			}
 		}
 
 		at, err := labelValueFloat(node.Labels, labelNodeStatusAmbientTemp)
 		if err == nil {
-			estimator.NodeStatusSetAmbientTemp(base, at)
+			if err := base.SetFloat(estimator.NodeStatusAmbientTemp, at); err != nil {
+				return err
+			}
 		}
 
 		spd, err := labelValueFloat(node.Labels, labelNodeStatusStaticPressureDiff)
 		if err == nil {
-			estimator.NodeStatusSetStaticPressureDiff(base, spd)
+			if err := base.SetFloat(estimator.NodeStatusStaticPressureDiff, spd); err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -90,8 +90,10 @@ var (
 					Agents: []v1beta1.NodeMonitorAgent{},
 				},
 				PowerConsumptionPredictor: &v1beta1.PowerConsumptionPredictor{
-					Type:     v1beta1.PowerConsumptionPredictorTypeNone,
-					Endpoint: "",
+					PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+						Type:     v1beta1.PowerConsumptionPredictorTypeNone,
+						Endpoint: "",
+					},
 				},
 			},
 		},
@@ -121,8 +123,10 @@ var (
 					Agents: []v1beta1.NodeMonitorAgent{},
 				},
 				PowerConsumptionPredictor: &v1beta1.PowerConsumptionPredictor{
-					Type:     v1beta1.PowerConsumptionPredictorTypeNone,
-					Endpoint: "",
+					PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+						Type:     v1beta1.PowerConsumptionPredictorTypeNone,
+						Endpoint: "",
+					},
 				},
 			},
 			NodeConfigOverrides: map[string]*v1beta1.NodeConfig{
@@ -131,7 +135,9 @@ var (
 						Agents: []v1beta1.NodeMonitorAgent{{Type: v1beta1.NodeMonitorTypeFake}},
 					},
 					PowerConsumptionPredictor: &v1beta1.PowerConsumptionPredictor{
-						Type: v1beta1.PowerConsumptionPredictorTypeFake,
+						PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+							Type: v1beta1.PowerConsumptionPredictorTypeFake,
+						},
 					},
 				},
 				"wao-estimator-test-worker2": &v1beta1.NodeConfig{
@@ -139,7 +145,9 @@ var (
 						Agents: []v1beta1.NodeMonitorAgent{{Type: v1beta1.NodeMonitorTypeFake}},
 					},
 					PowerConsumptionPredictor: &v1beta1.PowerConsumptionPredictor{
-						Type: v1beta1.PowerConsumptionPredictorTypeFake,
+						PowerConsumptionPredictorSpec: v1beta1.PowerConsumptionPredictorSpec{
+							Type: v1beta1.PowerConsumptionPredictorTypeFake,
+						},
 					},
 				},
 			},
@@ -291,7 +299,7 @@ var _ = Describe("Estimator controller", func() {
 		estm, ok := estimatorReconciler.GetEstimators().Get(client.ObjectKeyFromObject(&ec).String())
 		Expect(ok).To(BeTrue())
 
-		watts, err := estm.EstimatePowerConsumption(ctx, 500, 5)
+		estimate, err := estm.EstimatePowerConsumption(ctx, 500, 5)
 		Expect(err).To(BeNil())
 		// wattMatrix=[
 		//	            [inf inf inf inf inf inf]  (control-plane)
@@ -304,7 +312,14 @@ var _ = Describe("Estimator controller", func() {
 		//              [ 10  20  30  40  50]
 		//            ]
 		// watts=       [  5  10  15  20  25]
-		Expect(watts).To(Equal([]float64{5, 10, 15, 20, 25}))
+		Expect(estimate.WattIncreases).To(Equal([]float64{5, 10, 15, 20, 25}))
+		Expect(estimate.Placements).To(Equal([][]map[string]int{
+			{{"wao-estimator-test-worker": 1}},
+			{{"wao-estimator-test-worker": 2}},
+			{{"wao-estimator-test-worker": 3}},
+			{{"wao-estimator-test-worker": 4}},
+			{{"wao-estimator-test-worker": 5}},
+		}))
 	})
 
 })
@@ -56,8 +56,25 @@ func vv(format string, a ...any) {
 	}
 }
 
-func reqPC(ctx context.Context, addr, hk, hv, ns, name string, cpuMilli, numWorkloads int) (*estimator.PowerConsumption, *estimator.Error, error) {
-	vv("INFO: estimate power consumption addr=%s hk=%s hv=%s ns=%s name=%s cpu_milli=%d num_workloads=%d", addr, hk, hv, ns, name, cpuMilli, numWorkloads)
+func reqPC(ctx context.Context, addr, hk, hv, ns, name string, request estimator.ResourceRequest, numWorkloads int) (*estimator.PowerConsumption, *estimator.Error, error) {
+	vv("INFO: estimate power consumption addr=%s hk=%s hv=%s ns=%s name=%s cpu_milli=%d memory_bytes=%d num_workloads=%d", addr, hk, hv, ns, name, request.CPUMilli, request.MemoryBytes, numWorkloads)
+	client, err := newClient(addr, hk, hv, ns, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.EstimatePowerConsumptionResources(ctx, request, numWorkloads)
+}
+
+func reqPCG(ctx context.Context, addr, hk, hv, ns, name string, workloads []estimator.WorkloadGroup) (*estimator.PowerConsumptionGroups, *estimator.Error, error) {
+	vv("INFO: estimate power consumption addr=%s hk=%s hv=%s ns=%s name=%s workloads=%+v", addr, hk, hv, ns, name, workloads)
+	client, err := newClient(addr, hk, hv, ns, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.EstimatePowerConsumptionGroups(ctx, workloads)
+}
+
+func newClient(addr, hk, hv, ns, name string) (*estimator.Client, error) {
 	opts := []estimator.ClientOption{}
 	if hk != "" && hv != "" {
 		opts = append(opts, estimator.ClientOptionAddRequestHeader(hk, hv))
@@ -69,11 +86,7 @@ func reqPC(ctx context.Context, addr, hk, hv, ns, name string, cpuMilli, numWork
 			Suffix: "\n",
 		}))
 	}
-	client, err := estimator.NewClient(addr, ns, name, opts...)
-	if err != nil {
-		return nil, nil, err
-	}
-	return client.EstimatePowerConsumption(ctx, cpuMilli, numWorkloads)
+	return estimator.NewClient(addr, ns, name, opts...)
 }
 
 func printPC(r io.Writer, pc *estimator.PowerConsumption) error {
@@ -84,6 +97,14 @@ func printPC(r io.Writer, pc *estimator.PowerConsumption) error {
 	return nil
 }
 
+func printPCG(r io.Writer, pc *estimator.PowerConsumptionGroups) error {
+	if pc.WattIncrease == nil {
+		return errors.New("got nil value")
+	}
+	fmt.Println(*pc.WattIncrease)
+	return nil
+}
+
 func csv2Ints(s string) ([]int, error) {
 	ss := strings.Split(s, ",")
 	ret := make([]int, len(ss))
@@ -110,7 +131,7 @@ func main() {
 	help := func(exitCode int) {
 		flag.Usage = func() {
 			fmt.Fprintf(os.Stderr, "Usage: %s [option]... <command>\n", os.Args[0])
-			fmt.Fprintf(os.Stderr, "\nCommands:\n  pc\testimate power consumption; -p=<cpu_milli>,<num_workloads>\n")
+			fmt.Fprintf(os.Stderr, "\nCommands:\n  pc\testimate power consumption; -p=<cpu_milli>,<num_workloads>[,<memory_bytes>]\n  pcg\testimate power consumption of workload groups; -p=<cpu_milli>,<count>[,<cpu_milli>,<count>]...\n")
 			fmt.Fprintf(os.Stderr, "\nOptions:\n")
 			flag.PrintDefaults()
 		}
@@ -144,12 +165,16 @@ func main() {
 		if err != nil {
 			help(1)
 		}
-		if len(params) != 2 {
+		if len(params) != 2 && len(params) != 3 {
 			help(1)
 		}
+		request := estimator.ResourceRequest{CPUMilli: params[0]}
+		if len(params) == 3 {
+			request.MemoryBytes = int64(params[2])
+		}
 		ctx, cncl := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cncl()
-		pc, apiErr, err := reqPC(ctx, *addr, hk, hv, ns, name, params[0], params[1])
+		pc, apiErr, err := reqPC(ctx, *addr, hk, hv, ns, name, request, params[1])
 		if err != nil {
 			v("ERROR: %v", err)
 			os.Exit(1)
@@ -162,6 +187,33 @@ func main() {
 			v("ERROR: %v", err)
 			os.Exit(1)
 		}
+	case "pcg":
+		params, err := csv2Ints(*p)
+		if err != nil {
+			help(1)
+		}
+		if len(params) == 0 || len(params)%2 != 0 {
+			help(1)
+		}
+		workloads := make([]estimator.WorkloadGroup, len(params)/2)
+		for i := range workloads {
+			workloads[i] = estimator.WorkloadGroup{CpuMilli: params[2*i], Count: params[2*i+1]}
+		}
+		ctx, cncl := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cncl()
+		pc, apiErr, err := reqPCG(ctx, *addr, hk, hv, ns, name, workloads)
+		if err != nil {
+			v("ERROR: %v", err)
+			os.Exit(1)
+		}
+		if apiErr != nil {
+			v("ERROR:\n  code: %v\n  message: %v", apiErr.Code, apiErr.Message)
+			os.Exit(1)
+		}
+		if err := printPCG(os.Stdout, pc); err != nil {
+			v("ERROR: %v", err)
+			os.Exit(1)
+		}
 	default:
 		help(1)
 	}
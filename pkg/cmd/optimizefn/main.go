@@ -32,9 +32,11 @@ func main() {
 		podNum = len(x[0])
 	}
 
-	a, err := estimator.ComputeLeastCostsFn(clusterNum, podNum, x)
+	a, patterns, approximate, err := estimator.ComputeLeastCostsFn(clusterNum, podNum, x)
 	if err != nil {
 		panic(err)
 	}
 	fmt.Println(a)
+	fmt.Println(patterns)
+	fmt.Println(approximate)
 }
@@ -89,10 +89,90 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetEstimators request
+	GetEstimators(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNamespacesNsEstimatorsName request
+	GetNamespacesNsEstimatorsName(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNamespacesNsEstimatorsNameNodes request
+	GetNamespacesNsEstimatorsNameNodes(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNamespacesNsEstimatorsNameNodesNode request
+	GetNamespacesNsEstimatorsNameNodesNode(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNamespacesNsEstimatorsNameNodesNodeStatushistory request
+	GetNamespacesNsEstimatorsNameNodesNodeStatushistory(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostNamespacesNsEstimatorsNameValuesPowerconsumption request with any body
 	PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBody(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostNamespacesNsEstimatorsNameValuesPowerconsumption(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups request with any body
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithBody(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetEstimators(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstimatorsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNamespacesNsEstimatorsName(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNamespacesNsEstimatorsNameRequest(c.Server, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNamespacesNsEstimatorsNameNodes(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNamespacesNsEstimatorsNameNodesRequest(c.Server, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNamespacesNsEstimatorsNameNodesNode(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNamespacesNsEstimatorsNameNodesNodeRequest(c.Server, ns, name, node)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNamespacesNsEstimatorsNameNodesNodeStatushistory(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequest(c.Server, ns, name, node)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBody(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -119,19 +199,59 @@ func (c *Client) PostNamespacesNsEstimatorsNameValuesPowerconsumption(ctx contex
 	return c.Client.Do(req)
 }
 
-// NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequest calls the generic PostNamespacesNsEstimatorsNameValuesPowerconsumption builder with application/json body
-func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequest(server string, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithBody(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestWithBody(c.Server, ns, name, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody(server, ns, name, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody generates requests for PostNamespacesNsEstimatorsNameValuesPowerconsumption with any type of body
-func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody(server string, ns string, name string, contentType string, body io.Reader) (*http.Request, error) {
+func (c *Client) PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequest(c.Server, ns, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetEstimatorsRequest generates requests for GetEstimators
+func NewGetEstimatorsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estimators")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetNamespacesNsEstimatorsNameRequest generates requests for GetNamespacesNsEstimatorsName
+func NewGetNamespacesNsEstimatorsNameRequest(server string, ns string, name string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -153,7 +273,7 @@ func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody(serv
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/namespaces/%s/estimators/%s/values/powerconsumption", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/namespaces/%s/estimators/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -163,123 +283,792 @@ func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody(serv
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewGetNamespacesNsEstimatorsNameNodesRequest generates requests for GetNamespacesNsEstimatorsNameNodes
+func NewGetNamespacesNsEstimatorsNameNodesRequest(server string, ns string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ns", runtime.ParamLocationPath, ns)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	operationPath := fmt.Sprintf("/namespaces/%s/estimators/%s/nodes", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
+
+	return req, nil
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostNamespacesNsEstimatorsNameValuesPowerconsumption request with any body
-	PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBodyWithResponse(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error)
+// NewGetNamespacesNsEstimatorsNameNodesNodeRequest generates requests for GetNamespacesNsEstimatorsNameNodesNode
+func NewGetNamespacesNsEstimatorsNameNodesNodeRequest(server string, ns string, name string, node string) (*http.Request, error) {
+	var err error
 
-	PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithResponse(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error)
-}
+	var pathParam0 string
 
-type PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *PowerConsumption
-	JSON400      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ns", runtime.ParamLocationPath, ns)
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "node", runtime.ParamLocationPath, node)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-// PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBodyWithResponse request with arbitrary body returning *PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse
-func (c *ClientWithResponses) PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBodyWithResponse(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error) {
-	rsp, err := c.PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBody(ctx, ns, name, contentType, body, reqEditors...)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithResponse(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error) {
-	rsp, err := c.PostNamespacesNsEstimatorsNameValuesPowerconsumption(ctx, ns, name, body, reqEditors...)
+	operationPath := fmt.Sprintf("/namespaces/%s/estimators/%s/nodes/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse(rsp)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
 }
 
-// ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse parses an HTTP response from a PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithResponse call
-func ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse(rsp *http.Response) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// NewGetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequest generates requests for GetNamespacesNsEstimatorsNameNodesNodeStatushistory
+func NewGetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequest(server string, ns string, name string, node string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ns", runtime.ParamLocationPath, ns)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest PowerConsumption
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "node", runtime.ParamLocationPath, node)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/namespaces/%s/estimators/%s/nodes/%s/statushistory", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequest calls the generic PostNamespacesNsEstimatorsNameValuesPowerconsumption builder with application/json body
+func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequest(server string, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody(server, ns, name, "application/json", bodyReader)
+}
+
+// NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody generates requests for PostNamespacesNsEstimatorsNameValuesPowerconsumption with any type of body
+func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestWithBody(server string, ns string, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ns", runtime.ParamLocationPath, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/namespaces/%s/estimators/%s/values/powerconsumption", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequest calls the generic PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups builder with application/json body
+func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequest(server string, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestWithBody(server, ns, name, "application/json", bodyReader)
+}
+
+// NewPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestWithBody generates requests for PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups with any type of body
+func NewPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestWithBody(server string, ns string, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ns", runtime.ParamLocationPath, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/namespaces/%s/estimators/%s/values/powerconsumptiongroups", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetEstimators request
+	GetEstimatorsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetEstimatorsResponse, error)
+
+	// GetNamespacesNsEstimatorsName request
+	GetNamespacesNsEstimatorsNameWithResponse(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameResponse, error)
+
+	// GetNamespacesNsEstimatorsNameNodes request
+	GetNamespacesNsEstimatorsNameNodesWithResponse(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameNodesResponse, error)
+
+	// GetNamespacesNsEstimatorsNameNodesNode request
+	GetNamespacesNsEstimatorsNameNodesNodeWithResponse(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameNodesNodeResponse, error)
+
+	// GetNamespacesNsEstimatorsNameNodesNodeStatushistory request
+	GetNamespacesNsEstimatorsNameNodesNodeStatushistoryWithResponse(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse, error)
+
+	// PostNamespacesNsEstimatorsNameValuesPowerconsumption request with any body
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBodyWithResponse(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error)
+
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithResponse(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error)
+
+	// PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups request with any body
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithBodyWithResponse(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse, error)
+
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithResponse(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse, error)
+}
+
+type GetEstimatorsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EstimatorList
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstimatorsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstimatorsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNamespacesNsEstimatorsNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EstimatorInfo
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNamespacesNsEstimatorsNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNamespacesNsEstimatorsNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNamespacesNsEstimatorsNameNodesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NodeList
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNamespacesNsEstimatorsNameNodesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNamespacesNsEstimatorsNameNodesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NodeInfo
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNamespacesNsEstimatorsNameNodesNodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNamespacesNsEstimatorsNameNodesNodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NodeStatusHistory
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PowerConsumption
+	JSON400      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PowerConsumptionGroups
+	JSON400      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetEstimatorsWithResponse request returning *GetEstimatorsResponse
+func (c *ClientWithResponses) GetEstimatorsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetEstimatorsResponse, error) {
+	rsp, err := c.GetEstimators(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstimatorsResponse(rsp)
+}
+
+// GetNamespacesNsEstimatorsNameWithResponse request returning *GetNamespacesNsEstimatorsNameResponse
+func (c *ClientWithResponses) GetNamespacesNsEstimatorsNameWithResponse(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameResponse, error) {
+	rsp, err := c.GetNamespacesNsEstimatorsName(ctx, ns, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNamespacesNsEstimatorsNameResponse(rsp)
+}
+
+// GetNamespacesNsEstimatorsNameNodesWithResponse request returning *GetNamespacesNsEstimatorsNameNodesResponse
+func (c *ClientWithResponses) GetNamespacesNsEstimatorsNameNodesWithResponse(ctx context.Context, ns string, name string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameNodesResponse, error) {
+	rsp, err := c.GetNamespacesNsEstimatorsNameNodes(ctx, ns, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNamespacesNsEstimatorsNameNodesResponse(rsp)
+}
+
+// GetNamespacesNsEstimatorsNameNodesNodeWithResponse request returning *GetNamespacesNsEstimatorsNameNodesNodeResponse
+func (c *ClientWithResponses) GetNamespacesNsEstimatorsNameNodesNodeWithResponse(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameNodesNodeResponse, error) {
+	rsp, err := c.GetNamespacesNsEstimatorsNameNodesNode(ctx, ns, name, node, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNamespacesNsEstimatorsNameNodesNodeResponse(rsp)
+}
+
+// GetNamespacesNsEstimatorsNameNodesNodeStatushistoryWithResponse request returning *GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse
+func (c *ClientWithResponses) GetNamespacesNsEstimatorsNameNodesNodeStatushistoryWithResponse(ctx context.Context, ns string, name string, node string, reqEditors ...RequestEditorFn) (*GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse, error) {
+	rsp, err := c.GetNamespacesNsEstimatorsNameNodesNodeStatushistory(ctx, ns, name, node, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse(rsp)
+}
+
+// PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBodyWithResponse request with arbitrary body returning *PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse
+func (c *ClientWithResponses) PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBodyWithResponse(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error) {
+	rsp, err := c.PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithBody(ctx, ns, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithResponse(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error) {
+	rsp, err := c.PostNamespacesNsEstimatorsNameValuesPowerconsumption(ctx, ns, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse(rsp)
+}
+
+// PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithBodyWithResponse request with arbitrary body returning *PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse
+func (c *ClientWithResponses) PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithBodyWithResponse(ctx context.Context, ns string, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse, error) {
+	rsp, err := c.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithBody(ctx, ns, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithResponse(ctx context.Context, ns string, name string, body PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse, error) {
+	rsp, err := c.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(ctx, ns, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(rsp)
+}
+
+// ParseGetEstimatorsResponse parses an HTTP response from a GetEstimatorsWithResponse call
+func ParseGetEstimatorsResponse(rsp *http.Response) (*GetEstimatorsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstimatorsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EstimatorList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNamespacesNsEstimatorsNameResponse parses an HTTP response from a GetNamespacesNsEstimatorsNameWithResponse call
+func ParseGetNamespacesNsEstimatorsNameResponse(rsp *http.Response) (*GetNamespacesNsEstimatorsNameResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNamespacesNsEstimatorsNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EstimatorInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNamespacesNsEstimatorsNameNodesResponse parses an HTTP response from a GetNamespacesNsEstimatorsNameNodesWithResponse call
+func ParseGetNamespacesNsEstimatorsNameNodesResponse(rsp *http.Response) (*GetNamespacesNsEstimatorsNameNodesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNamespacesNsEstimatorsNameNodesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NodeList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNamespacesNsEstimatorsNameNodesNodeResponse parses an HTTP response from a GetNamespacesNsEstimatorsNameNodesNodeWithResponse call
+func ParseGetNamespacesNsEstimatorsNameNodesNodeResponse(rsp *http.Response) (*GetNamespacesNsEstimatorsNameNodesNodeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNamespacesNsEstimatorsNameNodesNodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NodeInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse parses an HTTP response from a GetNamespacesNsEstimatorsNameNodesNodeStatushistoryWithResponse call
+func ParseGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse(rsp *http.Response) (*GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NodeStatusHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse parses an HTTP response from a PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithResponse call
+func ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse(rsp *http.Response) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PowerConsumption
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse parses an HTTP response from a PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithResponse call
+func ParsePostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(rsp *http.Response) (*PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PowerConsumptionGroups
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -15,9 +15,27 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List the registered Estimators.
+	// (GET /estimators)
+	GetEstimators(w http.ResponseWriter, r *http.Request)
+	// Get a registered Estimator.
+	// (GET /namespaces/{ns}/estimators/{name})
+	GetNamespacesNsEstimatorsName(w http.ResponseWriter, r *http.Request, ns string, name string)
+	// List the nodes of the Estimator with their current NodeStatus.
+	// (GET /namespaces/{ns}/estimators/{name}/nodes)
+	GetNamespacesNsEstimatorsNameNodes(w http.ResponseWriter, r *http.Request, ns string, name string)
+	// Get a node of the Estimator with its current NodeStatus.
+	// (GET /namespaces/{ns}/estimators/{name}/nodes/{node})
+	GetNamespacesNsEstimatorsNameNodesNode(w http.ResponseWriter, r *http.Request, ns string, name string, node string)
+	// Get the raw NodeStatus history of a node for debugging.
+	// (GET /namespaces/{ns}/estimators/{name}/nodes/{node}/statushistory)
+	GetNamespacesNsEstimatorsNameNodesNodeStatushistory(w http.ResponseWriter, r *http.Request, ns string, name string, node string)
 	// Send a power consumption estimate request.
 	// (POST /namespaces/{ns}/estimators/{name}/values/powerconsumption)
 	PostNamespacesNsEstimatorsNameValuesPowerconsumption(w http.ResponseWriter, r *http.Request, ns string, name string)
+	// Send a power consumption estimate request for groups of workloads with different shapes.
+	// (POST /namespaces/{ns}/estimators/{name}/values/powerconsumptiongroups)
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(w http.ResponseWriter, r *http.Request, ns string, name string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -29,6 +47,189 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetEstimators operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimators(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimators(w, r)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNamespacesNsEstimatorsName operation middleware
+func (siw *ServerInterfaceWrapper) GetNamespacesNsEstimatorsName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ns" -------------
+	var ns string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ns", runtime.ParamLocationPath, chi.URLParam(r, "ns"), &ns)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ns", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNamespacesNsEstimatorsName(w, r, ns, name)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNamespacesNsEstimatorsNameNodes operation middleware
+func (siw *ServerInterfaceWrapper) GetNamespacesNsEstimatorsNameNodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ns" -------------
+	var ns string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ns", runtime.ParamLocationPath, chi.URLParam(r, "ns"), &ns)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ns", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNamespacesNsEstimatorsNameNodes(w, r, ns, name)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNamespacesNsEstimatorsNameNodesNode operation middleware
+func (siw *ServerInterfaceWrapper) GetNamespacesNsEstimatorsNameNodesNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ns" -------------
+	var ns string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ns", runtime.ParamLocationPath, chi.URLParam(r, "ns"), &ns)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ns", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "node", runtime.ParamLocationPath, chi.URLParam(r, "node"), &node)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "node", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNamespacesNsEstimatorsNameNodesNode(w, r, ns, name, node)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNamespacesNsEstimatorsNameNodesNodeStatushistory operation middleware
+func (siw *ServerInterfaceWrapper) GetNamespacesNsEstimatorsNameNodesNodeStatushistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ns" -------------
+	var ns string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ns", runtime.ParamLocationPath, chi.URLParam(r, "ns"), &ns)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ns", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "node", runtime.ParamLocationPath, chi.URLParam(r, "node"), &node)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "node", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNamespacesNsEstimatorsNameNodesNodeStatushistory(w, r, ns, name, node)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // PostNamespacesNsEstimatorsNameValuesPowerconsumption operation middleware
 func (siw *ServerInterfaceWrapper) PostNamespacesNsEstimatorsNameValuesPowerconsumption(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -66,6 +267,43 @@ func (siw *ServerInterfaceWrapper) PostNamespacesNsEstimatorsNameValuesPowercons
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups operation middleware
+func (siw *ServerInterfaceWrapper) PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ns" -------------
+	var ns string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ns", runtime.ParamLocationPath, chi.URLParam(r, "ns"), &ns)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ns", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(w, r, ns, name)
+	})
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -179,13 +417,197 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimators", wrapper.GetEstimators)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/namespaces/{ns}/estimators/{name}", wrapper.GetNamespacesNsEstimatorsName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/namespaces/{ns}/estimators/{name}/nodes", wrapper.GetNamespacesNsEstimatorsNameNodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/namespaces/{ns}/estimators/{name}/nodes/{node}", wrapper.GetNamespacesNsEstimatorsNameNodesNode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/namespaces/{ns}/estimators/{name}/nodes/{node}/statushistory", wrapper.GetNamespacesNsEstimatorsNameNodesNodeStatushistory)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/namespaces/{ns}/estimators/{name}/values/powerconsumption", wrapper.PostNamespacesNsEstimatorsNameValuesPowerconsumption)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/namespaces/{ns}/estimators/{name}/values/powerconsumptiongroups", wrapper.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups)
+	})
 
 	return r
 }
 
+type GetEstimatorsRequestObject struct {
+}
+
+type GetEstimatorsResponseObject interface {
+	VisitGetEstimatorsResponse(w http.ResponseWriter) error
+}
+
+type GetEstimators200JSONResponse EstimatorList
+
+func (response GetEstimators200JSONResponse) VisitGetEstimatorsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEstimators401Response struct {
+}
+
+func (response GetEstimators401Response) VisitGetEstimatorsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetNamespacesNsEstimatorsNameRequestObject struct {
+	Ns   string `json:"ns"`
+	Name string `json:"name"`
+}
+
+type GetNamespacesNsEstimatorsNameResponseObject interface {
+	VisitGetNamespacesNsEstimatorsNameResponse(w http.ResponseWriter) error
+}
+
+type GetNamespacesNsEstimatorsName200JSONResponse EstimatorInfo
+
+func (response GetNamespacesNsEstimatorsName200JSONResponse) VisitGetNamespacesNsEstimatorsNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNamespacesNsEstimatorsName401Response struct {
+}
+
+func (response GetNamespacesNsEstimatorsName401Response) VisitGetNamespacesNsEstimatorsNameResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetNamespacesNsEstimatorsName404JSONResponse Error
+
+func (response GetNamespacesNsEstimatorsName404JSONResponse) VisitGetNamespacesNsEstimatorsNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNamespacesNsEstimatorsNameNodesRequestObject struct {
+	Ns   string `json:"ns"`
+	Name string `json:"name"`
+}
+
+type GetNamespacesNsEstimatorsNameNodesResponseObject interface {
+	VisitGetNamespacesNsEstimatorsNameNodesResponse(w http.ResponseWriter) error
+}
+
+type GetNamespacesNsEstimatorsNameNodes200JSONResponse NodeList
+
+func (response GetNamespacesNsEstimatorsNameNodes200JSONResponse) VisitGetNamespacesNsEstimatorsNameNodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNamespacesNsEstimatorsNameNodes401Response struct {
+}
+
+func (response GetNamespacesNsEstimatorsNameNodes401Response) VisitGetNamespacesNsEstimatorsNameNodesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetNamespacesNsEstimatorsNameNodes404JSONResponse Error
+
+func (response GetNamespacesNsEstimatorsNameNodes404JSONResponse) VisitGetNamespacesNsEstimatorsNameNodesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeRequestObject struct {
+	Ns   string `json:"ns"`
+	Name string `json:"name"`
+	Node string `json:"node"`
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeResponseObject interface {
+	VisitGetNamespacesNsEstimatorsNameNodesNodeResponse(w http.ResponseWriter) error
+}
+
+type GetNamespacesNsEstimatorsNameNodesNode200JSONResponse NodeInfo
+
+func (response GetNamespacesNsEstimatorsNameNodesNode200JSONResponse) VisitGetNamespacesNsEstimatorsNameNodesNodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNamespacesNsEstimatorsNameNodesNode401Response struct {
+}
+
+func (response GetNamespacesNsEstimatorsNameNodesNode401Response) VisitGetNamespacesNsEstimatorsNameNodesNodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetNamespacesNsEstimatorsNameNodesNode404JSONResponse Error
+
+func (response GetNamespacesNsEstimatorsNameNodesNode404JSONResponse) VisitGetNamespacesNsEstimatorsNameNodesNodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequestObject struct {
+	Ns   string `json:"ns"`
+	Name string `json:"name"`
+	Node string `json:"node"`
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponseObject interface {
+	VisitGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse(w http.ResponseWriter) error
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeStatushistory200JSONResponse NodeStatusHistory
+
+func (response GetNamespacesNsEstimatorsNameNodesNodeStatushistory200JSONResponse) VisitGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeStatushistory401Response struct {
+}
+
+func (response GetNamespacesNsEstimatorsNameNodesNodeStatushistory401Response) VisitGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetNamespacesNsEstimatorsNameNodesNodeStatushistory404JSONResponse Error
+
+func (response GetNamespacesNsEstimatorsNameNodesNodeStatushistory404JSONResponse) VisitGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestObject struct {
 	Ns   string `json:"ns"`
 	Name string `json:"name"`
@@ -240,11 +662,83 @@ func (response PostNamespacesNsEstimatorsNameValuesPowerconsumption500JSONRespon
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestObject struct {
+	Ns   string `json:"ns"`
+	Name string `json:"name"`
+	Body *PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponseObject interface {
+	VisitPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(w http.ResponseWriter) error
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups200JSONResponse PowerConsumptionGroups
+
+func (response PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups200JSONResponse) VisitPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups400JSONResponse Error
+
+func (response PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups400JSONResponse) VisitPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups401Response struct {
+}
+
+func (response PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups401Response) VisitPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups404JSONResponse Error
+
+func (response PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups404JSONResponse) VisitPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups500JSONResponse Error
+
+func (response PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups500JSONResponse) VisitPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List the registered Estimators.
+	// (GET /estimators)
+	GetEstimators(ctx context.Context, request GetEstimatorsRequestObject) (GetEstimatorsResponseObject, error)
+	// Get a registered Estimator.
+	// (GET /namespaces/{ns}/estimators/{name})
+	GetNamespacesNsEstimatorsName(ctx context.Context, request GetNamespacesNsEstimatorsNameRequestObject) (GetNamespacesNsEstimatorsNameResponseObject, error)
+	// List the nodes of the Estimator with their current NodeStatus.
+	// (GET /namespaces/{ns}/estimators/{name}/nodes)
+	GetNamespacesNsEstimatorsNameNodes(ctx context.Context, request GetNamespacesNsEstimatorsNameNodesRequestObject) (GetNamespacesNsEstimatorsNameNodesResponseObject, error)
+	// Get a node of the Estimator with its current NodeStatus.
+	// (GET /namespaces/{ns}/estimators/{name}/nodes/{node})
+	GetNamespacesNsEstimatorsNameNodesNode(ctx context.Context, request GetNamespacesNsEstimatorsNameNodesNodeRequestObject) (GetNamespacesNsEstimatorsNameNodesNodeResponseObject, error)
+	// Get the raw NodeStatus history of a node for debugging.
+	// (GET /namespaces/{ns}/estimators/{name}/nodes/{node}/statushistory)
+	GetNamespacesNsEstimatorsNameNodesNodeStatushistory(ctx context.Context, request GetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequestObject) (GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponseObject, error)
 	// Send a power consumption estimate request.
 	// (POST /namespaces/{ns}/estimators/{name}/values/powerconsumption)
 	PostNamespacesNsEstimatorsNameValuesPowerconsumption(ctx context.Context, request PostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestObject) (PostNamespacesNsEstimatorsNameValuesPowerconsumptionResponseObject, error)
+	// Send a power consumption estimate request for groups of workloads with different shapes.
+	// (POST /namespaces/{ns}/estimators/{name}/values/powerconsumptiongroups)
+	PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(ctx context.Context, request PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestObject) (PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponseObject, error)
 }
 
 type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
@@ -277,6 +771,140 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// GetEstimators operation middleware
+func (sh *strictHandler) GetEstimators(w http.ResponseWriter, r *http.Request) {
+	var request GetEstimatorsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEstimators(ctx, request.(GetEstimatorsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEstimators")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEstimatorsResponseObject); ok {
+		if err := validResponse.VisitGetEstimatorsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetNamespacesNsEstimatorsName operation middleware
+func (sh *strictHandler) GetNamespacesNsEstimatorsName(w http.ResponseWriter, r *http.Request, ns string, name string) {
+	var request GetNamespacesNsEstimatorsNameRequestObject
+
+	request.Ns = ns
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNamespacesNsEstimatorsName(ctx, request.(GetNamespacesNsEstimatorsNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNamespacesNsEstimatorsName")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNamespacesNsEstimatorsNameResponseObject); ok {
+		if err := validResponse.VisitGetNamespacesNsEstimatorsNameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetNamespacesNsEstimatorsNameNodes operation middleware
+func (sh *strictHandler) GetNamespacesNsEstimatorsNameNodes(w http.ResponseWriter, r *http.Request, ns string, name string) {
+	var request GetNamespacesNsEstimatorsNameNodesRequestObject
+
+	request.Ns = ns
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNamespacesNsEstimatorsNameNodes(ctx, request.(GetNamespacesNsEstimatorsNameNodesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNamespacesNsEstimatorsNameNodes")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNamespacesNsEstimatorsNameNodesResponseObject); ok {
+		if err := validResponse.VisitGetNamespacesNsEstimatorsNameNodesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetNamespacesNsEstimatorsNameNodesNode operation middleware
+func (sh *strictHandler) GetNamespacesNsEstimatorsNameNodesNode(w http.ResponseWriter, r *http.Request, ns string, name string, node string) {
+	var request GetNamespacesNsEstimatorsNameNodesNodeRequestObject
+
+	request.Ns = ns
+	request.Name = name
+	request.Node = node
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNamespacesNsEstimatorsNameNodesNode(ctx, request.(GetNamespacesNsEstimatorsNameNodesNodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNamespacesNsEstimatorsNameNodesNode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNamespacesNsEstimatorsNameNodesNodeResponseObject); ok {
+		if err := validResponse.VisitGetNamespacesNsEstimatorsNameNodesNodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetNamespacesNsEstimatorsNameNodesNodeStatushistory operation middleware
+func (sh *strictHandler) GetNamespacesNsEstimatorsNameNodesNodeStatushistory(w http.ResponseWriter, r *http.Request, ns string, name string, node string) {
+	var request GetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequestObject
+
+	request.Ns = ns
+	request.Name = name
+	request.Node = node
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNamespacesNsEstimatorsNameNodesNodeStatushistory(ctx, request.(GetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNamespacesNsEstimatorsNameNodesNodeStatushistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponseObject); ok {
+		if err := validResponse.VisitGetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
 // PostNamespacesNsEstimatorsNameValuesPowerconsumption operation middleware
 func (sh *strictHandler) PostNamespacesNsEstimatorsNameValuesPowerconsumption(w http.ResponseWriter, r *http.Request, ns string, name string) {
 	var request PostNamespacesNsEstimatorsNameValuesPowerconsumptionRequestObject
@@ -310,3 +938,37 @@ func (sh *strictHandler) PostNamespacesNsEstimatorsNameValuesPowerconsumption(w
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
 	}
 }
+
+// PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups operation middleware
+func (sh *strictHandler) PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(w http.ResponseWriter, r *http.Request, ns string, name string) {
+	var request PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestObject
+
+	request.Ns = ns
+	request.Name = name
+
+	var body PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(ctx, request.(PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponseObject); ok {
+		if err := validResponse.VisitPostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
@@ -18,22 +18,53 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7xW227jNhD9FYLto2Ip7vrFb2kQFMEW2QBpekFgBGNqbHHLWzmkUzfwvxdDyVZiJyh6",
-	"2T6ZpMk5Z25n9CyVt8E7dInk/FmS6tBCWV7F6CMvQvQBY9JYjpVvkX9bJBV1SNo7OZcXgs9FxBCR0CXt",
-	"1iJ1KNI2oPCrskY2WAkCiwKo3wrHO+3Ed34iK8nX5VxSitqt5a6SFolg/Sbg8JdoMYE2e8BilU3h72CD",
-	"Yc4PUrsNGN2KiL9lpCQXJ0i7SvKfOmLLD4qTI/p43y8/o0rM7NY/Ybz0jrIdOJ1EKuRHq43Rp+x/6FCA",
-	"9dklDs7l7T2JPbxYbgWC6sSTj78aD+2RM001a5pq2jTNyEq7hGuMTMtl+7h/SX8FfLgoOtigSF4sUYAx",
-	"XkHCY+DzavYm4hOk9KidigiE70AiJW3ZpggcNrG/LgLG9xx9mC2qh1l13lTns2raVNPZYlFJndAWkJWP",
-	"FpKcy9bnpcGxeFy2y57ZcAAxwvY0xYfsHAftNNu7ShKqHHXa3nGD9G5C0B9xe5FTxzvNvnYILUa2CJYN",
-	"/Hx2cXt99vHql5Fe/0ru2Kh2K9/3lEugEi9zNGwnpUDzul7r1OXlRHlb32DrQ/TTZjqtn8CfDSH1sdZE",
-	"GYkdNlqho9ItA4GLAKrDs+mkkdU/sb00fllb0K7+/vry6ubuqsQVo6VPqzuMG63wb5rkSOhk+NlPF5/E",
-	"1YvzDUbqa6aZnE8ahvIBHQQt5/KbclTJAKkr4a/ZRQqgkOpnR7v6AMF7sLirN2AyUl1qTh21KkSwmDBy",
-	"qR2X7M3eskgdpKIrB54iIvkcFQpNosVg/LZvlVIAzG5MvyP5suhSzFgNEsschmLnGsYVZJPkG7r0Fre9",
-	"op6Seo8I//xbKotKBk+lSlnmgAldt3Iubz2lQ8johg60iE9/LEm4Pc5BzwYpfevb7b4J0KW+s4LRqgDU",
-	"n6lP2Mj164grOZdf1ePoqoe5VZ+o8u5137Pf5YCCd4NcTZvmC+O/zuAQHu2dYAsGWRcpK4VEq2zMdsKV",
-	"/+E/ZNWP8jeoXA+DsaedD7T3k1JQZlAu8ELp/FTe7x3k1Pmo/zjc+vDliY+V73wSK59dDz77P6J272Bp",
-	"yrjs+wAnr0ZEEZSXw+Fhwb1D2VqIWzmXd+haAcMofNEThzG5D3/5JIJ1+YYZhXKx69HiZq9evbBLRhmu",
-	"HwbA+Gy32P0ZAAD///bmloTuCQAA",
+	"H4sIAAAAAAAC/+xbbVPkNvL/Kir9/y+SOsM8LJAL78gWtUclS6gjub0raopo7PaMsrbkk2RgjprvftWS",
+	"n8aWZzwLZDd7vMkyttzP/euW1HmkoUwzKUAYTU8fqQ6XkDL757lSUuEfmZIZKMPBPg5lBPhvBDpUPDNc",
+	"CnpKzwg+JwoyBRqE4WJBzBKIWWVAZGz/BiQYEM1SIEy7n0TgLy7IO3lIA4rL6SnVRnGxoOuApqA1W3gZ",
+	"Fq9IBIbxpGRoqSIpeGBplqDMN5SLO5bwiCj4dw7a0FmH0zqg+JIriPADq2TNvV4v579DaFCyc214yoxU",
+	"FyKWXTOlUnAjle5K/ssSyKWM4H2xguQaIjJfWfGFjEC3xL+hF1fvL84fMqkMqManNKDvwSge6rOri+bz",
+	"2Syg3EBquXdsWjxgSrEV/kYXdMW8ZGnluUpXokDLXIXQtnAEMcsTn2UdfZ2xsIeJfUXMkpkeXoRrEkGW",
+	"yBVEe/FFW/rtL/J0DqpUz2f0NzVFLgwsQCHJTEHEQyR0G7JwaTX6fwUxPaX/N6ozaVSk0eiqWv8Wl18b",
+	"ZnSDTm98XMl7UG+l0HlqH19V6wdEy/ufrkHdgbp6W322Z0QoYNGqK9mHJZglKMLEasN0ZMk0Yf1CBwSc",
+	"U7kUmsSMJ+SemyU5V6ry9qXEANZnd4wnbJ4AsQFgciXIXy5ETHhMhDQNjJhLmQATndSto62I7DIQSrWC",
+	"Ojc3HLE1yX/i2nSTHMrXPW6sPtdEqgiU81wlIWEisr8OacM72+JpE3Q6nmuZoiGeTzk0eA/I98LuLyXE",
+	"lvDrRW2XtzuhD31stkW7QwUXBhD143sfPvpQwfAUtGFp5o1vUTMhMgxzpRzmxFKlzNBTGjEDB0iD7qoh",
+	"hRHqKtLk3ecPfzGx8vTEWMK0KQWOCbBwuWFiDLGtNh4ce3W4eBBjj3LXQI4udHnLWdDv4GcodLYeNIqd",
+	"RzB6L9VHUAcTb0RVGLInljcZBkSm3BiICqAjoRQxX+SqW/K88O5LQsNMPsir13bltWCZXkrjRdRN1CxI",
+	"B2Vg9kWzHzW31WV81cbKvSJ0EDA6CfqkduY4F0atusIPhTaLXBpcT3PHkhyeAlrasAT6C3LFA1slmUT2",
+	"EROEG01S9kA2cbqqnMPA0BG+ZxrVGYqF5QOfmZp7gtoyIk/RKnEimUGHC/xvlCvbNdDASu21jSXh5+RE",
+	"t82J6/gCUlLUuOnQEEoRaQuSSF/j2jGWpUnLW9Pjw+NZU3WZz5OG3o58J9Ds21LEIfjvYu9vXBvpiz4h",
+	"ox5Vn4Rgukj9nqxU7J7UwpFqNZlDLBUQtlgoWFizBlXyxkqmVhqMR232SuI2IA1IZ9rUYrt1K7od8w5J",
+	"h5KLzQjDPoIYnhM2DiwnFkUcKbPkakOCYZZx2LQO+kJek28a/voIK2KkFR7ww28x9JuqHNKOvdpxXBmm",
+	"UsJn43ap61qYZZmSD9iV+uJYIYLF5J4Zc8tFqIBpQARb2ZI4Byt1AkybgMDh4tBpIZM7UHiskCVFk+jK",
+	"SIztD0mYWkC5829lRcwSDTMfMIZZfpvyJOH+jGCpzIXBfHt79asmpamwYtkODLMtkaxdu8fB8XgcTMfj",
+	"sXd3CQ8GRATRbbnz3RoqVchxYU6OqI/gNsFLZtU2e4sagdt1SpGsyq1nvXFyezl89lth5fOC9N9LyqNH",
+	"hKf1byQGZnLV2bM+UnHHI84OQ5mOFllOTydrX3ylkEq1up2vTF8DUevn1mKo2+XPptx7S/cHpFnp01Jn",
+	"Mv7uzXdHk79Oj2bBEDeJPL0tpdmpV7WQLNkdYGLPgbAkkSEznWZxEhz7zzESFkJaHvp12dXvyTeYTK68",
+	"FCBSH55UsnxLWLjkcIencNa4RTciWrkcEIxewkWY5Bh7eYZEJ+NxkyPmbU2kvUe4eaxr2elkHVQ/pzZq",
+	"NnYE1R99OdS1TCfm2puI9u/No5wdjOpS4LF5HXSbNq9e2CffutYyUzLKwwLv6mMpu71y8GcjN2ZJMmfh",
+	"xwb1Tu7V5qz2FjRoWLWA9VzdAfWm5aaP/RFVnEVARDKkRsrlJAPVB5c3x7Pg5jiYjIPJcTAdB9PjDe/u",
+	"bMa6ztqU9DZBUXq21VbKucyxP5SdmhQXRxcxV9rUwVttwW3oBg5UWHWmxeM2vCjIpLKbBK6KXbzj2TbF",
+	"UfB9MDkKJt8H06NntkKeZX1WsK++ICucBJNJMDkJppNgevI0M7RP/KuC38bjIY3OOyXzTHfbnefG2cqy",
+	"C2TYRN0NzwyB2bZpmzhwMwmmsyYE3IyDo9l6w+B9SNc+juliaxtK+7H2FVs3c3VPaL3HPQtLEqtHHUNM",
+	"9TcM00G73JZYA3G0AyB9+PGMiDE9+hR9hiHiZ9HnZKA+29tJCx96WDs5aNf+oSBjgXAn2O4AV9+lmVcN",
+	"bZjh2vCwOlWus5XYCzqiuQihdbGIO3f0mIGozzXuY64JCHxsDbEJ7Utudl4uNrFDY/I3jkUsh3a7Ph0P",
+	"2zEkIHbxBmFUXQRsY/6Q2Q2QFKDthnoFhsAdD7sw8Ma/QU251rCX1sgmtsnCRa02kaqUxsd4pwFa4WRd",
+	"UUnnrOMLrM0g9Uw25MLsUq7Ol0Ihm0qdbdeJ137PdKqwjTUeMBz96Q4YNjT6qk8bnqLpsx89bOmBXTLM",
+	"fAeDGsJccbO6Ruwvj/X4j7A6y80Sf3G0zxJYZJsdd+tG/3lwdnVx8OP5v2o53Fd0jUR5cfEZSmFYaNMw",
+	"VwnSMSbTp6PRgptlPrc+u4RIZkpOx9Pp6J7Jg+qee8S1zsFOWCQ8BOE6pkKAswyh52B6OKbBp9CeJ3I+",
+	"ShkXo58u3p5fXp8jGwMq1T/H2NnxEPYkaW8ETIKffTj7uS5QeMgKSrs4Gx9ODsfISmYgWMbpKX1jHwU0",
+	"Y2ZpzT/aHERYgLUf5rI9lL+I6Cl9B+a8XoWO15kUBZxPx+PS+OAwkGVZwkP7+eh37Y5yXbEfPKNgb/+s",
+	"c58yGbEO6NF40s2/XwXLzVIq/h8sIs24tCnejMibGea3ztOU4aUKRcFsYilYcG0s91ogexrOFphN9fwH",
+	"nSGHUSUggoxeN+xegM4281fDTvpS1+wu3e3qy7vD3YzucMdge+Oqo+cT0s0VdIWrm7aqk9jT2e/AEOb1",
+	"dJ+jMbEUS8GAcuXiGWbWLCRivtaAKDRtwq9ROQQNcxWgTk+rGTfPwMn+Q3s+QcoIfIoos0HpMaru/vdP",
+	"kstqiuuFMqWaWOhJkp7hhK8tYSp0LPRtR1PZlnBF7HyUMI3L4dec+kw5NXrEf9ZPSK1Ld4v+oum1rQa5",
+	"qYUvNZtsQkXwxDJkSfgzihv9mk8vm09bRSmHZnzMZTSUeXXe+yzZPHKDdst6HOgJuX29QeuFE31zjqkn",
+	"470zRRvu+JrRwHQnqwrnoA0KrMAT5Qjm+WLBxeIVDP7XwMDNWo3svU7Ymqt6dfne/VRAM6k9AHol9RYE",
+	"/Yd1wlXbB04a0OYHGa2eDXw6I3Tr9bqt9/oFwdvP34uD9pJFotUNRETnYQhax3mSrApIHr88JF8U/2df",
+	"cY1aiV0O/BGdI1OIvtxa0qwhAT3+I6z2q73UIkYSlwewZ/m6BhERVlw3N3Kiuopuzlt+8llaH/ot6mGL",
+	"Vwz8rBhYeOKPQcJixuYz42FTij8JKraFf8XGLwIbbW/vG8SwxwERj2OwhwF6yTLov5WwsuFckcNAd7Hl",
+	"Zonc8uoCrP5sPVv/dwBMvrkK+T8AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -3,10 +3,22 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.12.4 DO NOT EDIT.
 package api
 
+import (
+	"time"
+)
+
 const (
 	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
 )
 
+// Defines values for NodeStatusEntryType.
+const (
+	Bool     NodeStatusEntryType = "bool"
+	Duration NodeStatusEntryType = "duration"
+	Float    NodeStatusEntryType = "float"
+	Int      NodeStatusEntryType = "int"
+)
+
 // Error defines model for Error.
 type Error struct {
 	// Code A code representing the type of the error, same as error name in Go.
@@ -16,17 +28,192 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// EstimatorInfo defines model for EstimatorInfo.
+type EstimatorInfo struct {
+	// Monitors The NodeMonitors used by the nodes.
+	Monitors []string `json:"monitors"`
+
+	// Name Name of the Estimator resource.
+	Name string `json:"name"`
+
+	// Namespace Namespace that the Estimator resource is deployed.
+	Namespace string `json:"namespace"`
+
+	// Nodes The number of the nodes.
+	Nodes int `json:"nodes"`
+
+	// PredictionCache The statistics of the prediction cache since the Estimator was created, only available if the cache is enabled.
+	PredictionCache *PredictionCacheStats `json:"prediction_cache,omitempty"`
+
+	// Predictors The PowerConsumptionPredictors used by the nodes.
+	Predictors []string `json:"predictors"`
+
+	// Ready Whether any of the nodes has a PowerConsumptionPredictor, estimations fail with ErrEstimatorNoNodesAvailable or return +Inf if not.
+	Ready bool `json:"ready"`
+}
+
+// EstimatorList defines model for EstimatorList.
+type EstimatorList struct {
+	// Estimators The Estimators ordered by namespace and name.
+	Estimators []EstimatorInfo `json:"estimators"`
+}
+
+// NodeError defines model for NodeError.
+type NodeError struct {
+	// Message The error message.
+	Message string `json:"message"`
+
+	// Source The NodeMonitor or the PowerConsumptionPredictor that returned the error.
+	Source string `json:"source"`
+
+	// Timestamp When the error occurred.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NodeInfo defines model for NodeInfo.
+type NodeInfo struct {
+	// Errors The last error of each NodeMonitor and the PowerConsumptionPredictor.
+	Errors []NodeError `json:"errors"`
+
+	// Monitors The NodeMonitors of the node.
+	Monitors []string `json:"monitors"`
+
+	// Name The name of the node.
+	Name string `json:"name"`
+
+	// Predictor The PowerConsumptionPredictor of the node, omitted if not configured.
+	Predictor *string            `json:"predictor,omitempty"`
+	Status    NodeStatusSnapshot `json:"status"`
+}
+
+// NodeList defines model for NodeList.
+type NodeList struct {
+	// Nodes The nodes ordered by name.
+	Nodes []NodeInfo `json:"nodes"`
+}
+
+// NodeStatusEntry defines model for NodeStatusEntry.
+type NodeStatusEntry struct {
+	// Source The NodeMonitor that set the value.
+	Source *string `json:"source,omitempty"`
+
+	// Stale Whether the value is older than its max age.
+	Stale *bool `json:"stale,omitempty"`
+
+	// Timestamp When the value was set.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Type The type of the value.
+	Type NodeStatusEntryType `json:"type"`
+
+	// Value The value as a number, durations in seconds and bools as 0 or 1.
+	Value float64 `json:"value"`
+}
+
+// NodeStatusEntryType The type of the value.
+type NodeStatusEntryType string
+
+// NodeStatusHistory defines model for NodeStatusHistory.
+type NodeStatusHistory struct {
+	// Node The name of the node.
+	Node string `json:"node"`
+
+	// Snapshots The raw NodeStatus snapshots before aggregation, ordered from the oldest.
+	Snapshots []NodeStatusSnapshot `json:"snapshots"`
+}
+
+// NodeStatusSnapshot defines model for NodeStatusSnapshot.
+type NodeStatusSnapshot struct {
+	// Timestamp When the snapshot was taken.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Values The values (NodeStatus key to the entry) in the snapshot.
+	Values map[string]NodeStatusEntry `json:"values"`
+}
+
 // PowerConsumption defines model for PowerConsumption.
 type PowerConsumption struct {
+	// Approximate True if watt_increases may not be the least, e.g. the solver sampled the nodes for a large request.
+	Approximate *bool `json:"approximate,omitempty"`
+
 	// CpuMilli The amount of CPUs required by each workload.
 	CpuMilli int `json:"cpu_milli"`
 
+	// ExtendedResources The amount of extended resources required by each workload, used only by the predictors with the `requestExtendedResources/{name}` features.
+	ExtendedResources *map[string]int64 `json:"extended_resources,omitempty"`
+
+	// MemoryBytes The amount of memory in bytes required by each workload, used only by the predictors with the `requestMemoryBytes` feature.
+	MemoryBytes *int64 `json:"memory_bytes,omitempty"`
+
 	// NumWorkloads The amount of workloads have to be allocated.
 	NumWorkloads int `json:"num_workloads"`
 
+	// Placements The placements (node name to the number of workloads) achieving each value in watt_increases, ties included up to 100 placements for each value.
+	Placements *[][]map[string]int `json:"placements,omitempty"`
+
+	// Predictors The predictors (node name to predictor name) that produced the predictions of nodes with fallback predictors.
+	Predictors *map[string]string `json:"predictors,omitempty"`
+
 	// WattIncreases The estimated power increase per workload.
 	WattIncreases *[]float64 `json:"watt_increases,omitempty"`
+
+	// WattIncreasesLower The lower bounds of watt_increases for the first placement of each value, only available if the predictors report their error bounds.
+	WattIncreasesLower *[]float64 `json:"watt_increases_lower,omitempty"`
+
+	// WattIncreasesUpper The upper bounds of watt_increases for the first placement of each value, only available if the predictors report their error bounds.
+	WattIncreasesUpper *[]float64 `json:"watt_increases_upper,omitempty"`
+}
+
+// PowerConsumptionGroups defines model for PowerConsumptionGroups.
+type PowerConsumptionGroups struct {
+	// Placements The placements (node name to the number of workloads of each group) achieving watt_increase, ties included up to 100 placements.
+	Placements *[]map[string][]int `json:"placements,omitempty"`
+
+	// Predictors The predictors (node name to predictor name) that produced the predictions of nodes with fallback predictors.
+	Predictors *map[string]string `json:"predictors,omitempty"`
+
+	// WattIncrease The estimated power increase when all the workloads are allocated.
+	WattIncrease *float64 `json:"watt_increase,omitempty"`
+
+	// WattIncreaseLower The lower bound of watt_increase for the first placement, only available if the predictors report their error bounds.
+	WattIncreaseLower *float64 `json:"watt_increase_lower,omitempty"`
+
+	// WattIncreaseUpper The upper bound of watt_increase for the first placement, only available if the predictors report their error bounds.
+	WattIncreaseUpper *float64 `json:"watt_increase_upper,omitempty"`
+
+	// Workloads The groups of workloads have to be allocated.
+	Workloads []WorkloadGroup `json:"workloads"`
+}
+
+// PredictionCacheStats The statistics of the prediction cache since the Estimator was created, only available if the cache is enabled.
+type PredictionCacheStats struct {
+	// Hits The number of predictions served from the cache.
+	Hits int64 `json:"hits"`
+
+	// Len The number of entries including expired ones not yet evicted.
+	Len int `json:"len"`
+
+	// Misses The number of predictions not found in the cache or expired.
+	Misses int64 `json:"misses"`
+}
+
+// WorkloadGroup defines model for WorkloadGroup.
+type WorkloadGroup struct {
+	// Count The number of workloads in the group.
+	Count int `json:"count"`
+
+	// CpuMilli The amount of CPUs required by each workload in the group.
+	CpuMilli int `json:"cpu_milli"`
+
+	// ExtendedResources The amount of extended resources required by each workload in the group, used only by the predictors with the `requestExtendedResources/{name}` features.
+	ExtendedResources *map[string]int64 `json:"extended_resources,omitempty"`
+
+	// MemoryBytes The amount of memory in bytes required by each workload in the group, used only by the predictors with the `requestMemoryBytes` feature.
+	MemoryBytes *int64 `json:"memory_bytes,omitempty"`
 }
 
 // PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody defines body for PostNamespacesNsEstimatorsNameValuesPowerconsumption for application/json ContentType.
 type PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody = PowerConsumption
+
+// PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody defines body for PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups for application/json ContentType.
+type PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody = PowerConsumptionGroups
@@ -49,11 +49,22 @@ func NewClient(server string, estimatorNamespace, estimatorName string, opts ...
 }
 
 func (c *Client) EstimatePowerConsumption(ctx context.Context, cpuMilli, numWorkloads int) (pc *PowerConsumption, apiErr *Error, requestErr error) {
+	return c.EstimatePowerConsumptionResources(ctx, ResourceRequest{CPUMilli: cpuMilli}, numWorkloads)
+}
+
+// EstimatePowerConsumptionResources is EstimatePowerConsumption with the resources requested by each workload.
+func (c *Client) EstimatePowerConsumptionResources(ctx context.Context, request ResourceRequest, numWorkloads int) (pc *PowerConsumption, apiErr *Error, requestErr error) {
 	body := api.PostNamespacesNsEstimatorsNameValuesPowerconsumptionJSONRequestBody{
-		CpuMilli:      cpuMilli,
+		CpuMilli:      request.CPUMilli,
 		NumWorkloads:  numWorkloads,
 		WattIncreases: nil,
 	}
+	if request.MemoryBytes != 0 {
+		body.MemoryBytes = &request.MemoryBytes
+	}
+	if len(request.ExtendedResources) != 0 {
+		body.ExtendedResources = &request.ExtendedResources
+	}
 	resp, err := c.c.PostNamespacesNsEstimatorsNameValuesPowerconsumptionWithResponse(ctx, c.reqNS, c.reqName, body)
 	if err != nil {
 		return nil, nil, err
@@ -61,9 +72,14 @@ func (c *Client) EstimatePowerConsumption(ctx context.Context, cpuMilli, numWork
 	switch resp.StatusCode() {
 	case http.StatusOK:
 		// HACK: restore math.MaxFloat64 to math.Inf(1) (see also: server.go)
-		for i := range *resp.JSON200.WattIncreases {
-			if (*resp.JSON200.WattIncreases)[i] == math.MaxFloat64 {
-				(*resp.JSON200.WattIncreases)[i] = math.Inf(1)
+		for _, vs := range []*[]float64{resp.JSON200.WattIncreases, resp.JSON200.WattIncreasesLower, resp.JSON200.WattIncreasesUpper} {
+			if vs == nil {
+				continue
+			}
+			for i := range *vs {
+				if (*vs)[i] == math.MaxFloat64 {
+					(*vs)[i] = math.Inf(1)
+				}
 			}
 		}
 		return resp.JSON200, nil, nil
@@ -79,3 +95,121 @@ func (c *Client) EstimatePowerConsumption(ctx context.Context, cpuMilli, numWork
 		return nil, nil, fmt.Errorf("%v (%w)", resp.Status(), ErrUnexpected)
 	}
 }
+
+func (c *Client) EstimatePowerConsumptionGroups(ctx context.Context, workloads []WorkloadGroup) (pc *PowerConsumptionGroups, apiErr *Error, requestErr error) {
+	body := api.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsJSONRequestBody{
+		Workloads: workloads,
+	}
+	resp, err := c.c.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsWithResponse(ctx, c.reqNS, c.reqName, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		// HACK: restore math.MaxFloat64 to math.Inf(1) (see also: server.go)
+		for _, v := range []*float64{resp.JSON200.WattIncrease, resp.JSON200.WattIncreaseLower, resp.JSON200.WattIncreaseUpper} {
+			if v != nil && *v == math.MaxFloat64 {
+				*v = math.Inf(1)
+			}
+		}
+		return resp.JSON200, nil, nil
+	case http.StatusBadRequest:
+		return nil, resp.JSON400, nil
+	case http.StatusUnauthorized:
+		return nil, &api.Error{Code: ErrClientUnauthorized.Error(), Message: "client unauthorized"}, nil
+	case http.StatusNotFound:
+		return nil, resp.JSON404, nil
+	case http.StatusInternalServerError:
+		return nil, resp.JSON500, nil
+	default:
+		return nil, nil, fmt.Errorf("%v (%w)", resp.Status(), ErrUnexpected)
+	}
+}
+
+// GetNodeStatusHistory returns the raw NodeStatus snapshots of the node for debugging.
+func (c *Client) GetNodeStatusHistory(ctx context.Context, node string) (history *NodeStatusHistory, apiErr *Error, requestErr error) {
+	resp, err := c.c.GetNamespacesNsEstimatorsNameNodesNodeStatushistoryWithResponse(ctx, c.reqNS, c.reqName, node)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.JSON200, nil, nil
+	case http.StatusUnauthorized:
+		return nil, &api.Error{Code: ErrClientUnauthorized.Error(), Message: "client unauthorized"}, nil
+	case http.StatusNotFound:
+		return nil, resp.JSON404, nil
+	default:
+		return nil, nil, fmt.Errorf("%v (%w)", resp.Status(), ErrUnexpected)
+	}
+}
+
+// ListNodes returns the nodes of the Estimator with their current NodeStatus.
+func (c *Client) ListNodes(ctx context.Context) (nodes *NodeList, apiErr *Error, requestErr error) {
+	resp, err := c.c.GetNamespacesNsEstimatorsNameNodesWithResponse(ctx, c.reqNS, c.reqName)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.JSON200, nil, nil
+	case http.StatusUnauthorized:
+		return nil, &api.Error{Code: ErrClientUnauthorized.Error(), Message: "client unauthorized"}, nil
+	case http.StatusNotFound:
+		return nil, resp.JSON404, nil
+	default:
+		return nil, nil, fmt.Errorf("%v (%w)", resp.Status(), ErrUnexpected)
+	}
+}
+
+// GetNode returns the node with its current NodeStatus, the NodeMonitors, the PowerConsumptionPredictor and the last errors.
+func (c *Client) GetNode(ctx context.Context, node string) (info *NodeInfo, apiErr *Error, requestErr error) {
+	resp, err := c.c.GetNamespacesNsEstimatorsNameNodesNodeWithResponse(ctx, c.reqNS, c.reqName, node)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.JSON200, nil, nil
+	case http.StatusUnauthorized:
+		return nil, &api.Error{Code: ErrClientUnauthorized.Error(), Message: "client unauthorized"}, nil
+	case http.StatusNotFound:
+		return nil, resp.JSON404, nil
+	default:
+		return nil, nil, fmt.Errorf("%v (%w)", resp.Status(), ErrUnexpected)
+	}
+}
+
+// ListEstimators returns all the registered Estimators, the namespace and the name of the Client are ignored.
+func (c *Client) ListEstimators(ctx context.Context) (estimators *EstimatorList, apiErr *Error, requestErr error) {
+	resp, err := c.c.GetEstimatorsWithResponse(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.JSON200, nil, nil
+	case http.StatusUnauthorized:
+		return nil, &api.Error{Code: ErrClientUnauthorized.Error(), Message: "client unauthorized"}, nil
+	default:
+		return nil, nil, fmt.Errorf("%v (%w)", resp.Status(), ErrUnexpected)
+	}
+}
+
+// GetEstimator returns the Estimator with the namespace and the name of the Client.
+func (c *Client) GetEstimator(ctx context.Context) (estimator *EstimatorInfo, apiErr *Error, requestErr error) {
+	resp, err := c.c.GetNamespacesNsEstimatorsNameWithResponse(ctx, c.reqNS, c.reqName)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.JSON200, nil, nil
+	case http.StatusUnauthorized:
+		return nil, &api.Error{Code: ErrClientUnauthorized.Error(), Message: "client unauthorized"}, nil
+	case http.StatusNotFound:
+		return nil, resp.JSON404, nil
+	default:
+		return nil, nil, fmt.Errorf("%v (%w)", resp.Status(), ErrUnexpected)
+	}
+}
@@ -17,6 +17,13 @@ func init() {
 }
 
 type PowerConsumption = api.PowerConsumption
+type PowerConsumptionGroups = api.PowerConsumptionGroups
+type WorkloadGroup = api.WorkloadGroup
+type NodeStatusHistory = api.NodeStatusHistory
+type NodeInfo = api.NodeInfo
+type NodeList = api.NodeList
+type EstimatorInfo = api.EstimatorInfo
+type EstimatorList = api.EstimatorList
 
 type ClientOption = api.ClientOption
 type Error = api.Error
@@ -28,6 +35,7 @@ var (
 
 	ErrClientUnauthorized      = errors.New("ErrClientUnauthorized")
 	ErrServerEstimatorNotFound = errors.New("ErrServerEstimatorNotFound")
+	ErrServerNodeNotFound      = errors.New("ErrServerNodeNotFound")
 
 	ErrEstimator                 = errors.New("ErrEstimator")
 	ErrEstimatorNoNodesAvailable = errors.New("ErrEstimatorNoNodesAvailable")
@@ -36,10 +44,13 @@ var (
 	ErrNodeMonitor         = errors.New("ErrNodeMonitor")
 	ErrNodeMonitorNotFound = errors.New("ErrNodeMonitorNotFound")
 
-	ErrNodeStatus = errors.New("ErrNodeStatus")
+	ErrNodeStatus      = errors.New("ErrNodeStatus")
+	ErrNodeStatusStale = errors.New("ErrNodeStatusStale")
 
 	ErrPCPredictor         = errors.New("ErrPCPredictor")
 	ErrPCPredictorNotFound = errors.New("ErrPCPredictorNotFound")
+
+	ErrCircuitOpen = errors.New("ErrCircuitOpen")
 )
 
 func GetErrorFromCode(apiErr Error) error {
@@ -55,6 +66,7 @@ var getErrorFromCode map[string]error = map[string]error{
 
 	ErrClientUnauthorized.Error():      ErrClientUnauthorized,
 	ErrServerEstimatorNotFound.Error(): ErrServerEstimatorNotFound,
+	ErrServerNodeNotFound.Error():      ErrServerNodeNotFound,
 
 	ErrEstimator.Error():                 ErrEstimator,
 	ErrEstimatorNoNodesAvailable.Error(): ErrEstimatorNoNodesAvailable,
@@ -63,10 +75,13 @@ var getErrorFromCode map[string]error = map[string]error{
 	ErrNodeMonitor.Error():         ErrNodeMonitor,
 	ErrNodeMonitorNotFound.Error(): ErrNodeMonitorNotFound,
 
-	ErrNodeStatus.Error(): ErrNodeStatus,
+	ErrNodeStatus.Error():      ErrNodeStatus,
+	ErrNodeStatusStale.Error(): ErrNodeStatusStale,
 
 	ErrNodeStatus.Error(): ErrNodeStatus,
 
 	ErrPCPredictor.Error():         ErrPCPredictor,
 	ErrPCPredictorNotFound.Error(): ErrPCPredictorNotFound,
+
+	ErrCircuitOpen.Error(): ErrCircuitOpen,
 }
@@ -10,7 +10,10 @@ import (
 
 type Estimator struct {
 	Nodes *Nodes
-	init  sync.Once
+	// PredictionCache is the cache shared by the CachedPCPredictors of Nodes, nil if disabled.
+	// It is only used to report the cache statistics.
+	PredictionCache *PCPredictionCache
+	init            sync.Once
 }
 
 func (e *Estimator) initOnce() {
@@ -21,17 +24,47 @@ func (e *Estimator) initOnce() {
 	})
 }
 
+// PowerConsumptionEstimate is the result of Estimator.EstimatePowerConsumption.
+type PowerConsumptionEstimate struct {
+	// WattIncreases[k-1] is the least increase in power consumption when k workloads are placed.
+	WattIncreases []float64
+	// Placements[k-1] holds the placements that achieve WattIncreases[k-1] (ties included),
+	// each placement maps node names to the number of workloads placed on them (nodes with no workloads are omitted).
+	// Placements[k-1] is empty if WattIncreases[k-1] is +Inf.
+	Placements [][]map[string]int
+	// WattIncreasesLower[k-1] and WattIncreasesUpper[k-1] are the bounds of WattIncreases[k-1] for Placements[k-1][0],
+	// both are nil unless any of the nodes reports the bounds (see DetailedPCPredictor),
+	// the predictions are used as the bounds of the other nodes.
+	WattIncreasesLower []float64
+	WattIncreasesUpper []float64
+	// Predictors maps node names to the predictors that produced their predictions,
+	// only nodes with predictors that report them (e.g. FallbackPCPredictor) are included.
+	Predictors map[string]string
+	// Approximate is true if WattIncreases may not be the least, see ComputeLeastCostsFunc.
+	Approximate bool
+}
+
 // EstimatePowerConsumption is a thread-safe function that
 // estimates power consumption with the given parameters.
 //
 // +Inf in the response represents errors in Node.GetStatus or PowerConsumptionPredictor.Predict.
 // The response will not contain -Inf or NaN, return an error instead if -Inf or NaN is encountered.
-func (e *Estimator) EstimatePowerConsumption(ctx context.Context, cpuMilli, numWorkloads int) ([]float64, error) {
+func (e *Estimator) EstimatePowerConsumption(ctx context.Context, cpuMilli, numWorkloads int) (*PowerConsumptionEstimate, error) {
+	return e.EstimatePowerConsumptionResources(ctx, ResourceRequest{CPUMilli: cpuMilli}, numWorkloads)
+}
+
+// EstimatePowerConsumptionResources is EstimatePowerConsumption with the resources requested by each workload,
+// nodes use PowerConsumptionPredictorV2 if available.
+func (e *Estimator) EstimatePowerConsumptionResources(ctx context.Context, request ResourceRequest, numWorkloads int) (*PowerConsumptionEstimate, error) {
 	e.initOnce()
 
 	if e.Nodes.Len() == 0 {
 		return nil, fmt.Errorf("no nodes available (%w)", ErrEstimatorNoNodesAvailable)
 	}
+	if err := validateResourceRequest(request); err != nil {
+		return nil, err
+	}
+	cpuMilli := request.CPUMilli
 
 	// init wattMatrix[node][workload]
 	lg.Debug().Msgf("init wattMatrix[%d][%d]", e.Nodes.Len(), numWorkloads+1)
@@ -43,20 +76,46 @@ func (e *Estimator) EstimatePowerConsumption(ctx context.Context, cpuMilli, numW
 	// prediction
 	wg := sync.WaitGroup{}
 	i := 0
+	nodeNames := make([]string, e.Nodes.Len())
+	nodeSources := make([]string, e.Nodes.Len())
+	nodeMaxWorkloads := make([]int, e.Nodes.Len())
+	// lowerMatrix[node][workload] and upperMatrix[node][workload] hold the bounds of the increases, nil if unknown
+	lowerMatrix := make([][]float64, e.Nodes.Len())
+	upperMatrix := make([][]float64, e.Nodes.Len())
 	e.Nodes.Range(func(nodeName string, node *Node) bool {
 		nodeIdx := i
+		nodeNames[nodeIdx] = nodeName
 		wg.Add(1)
 		// NOTE: no need to sync, the goroutines below only write different slice elements
 		go func() {
 			defer wg.Done()
-			for j := 0; j < numWorkloads+1; j++ {
-				watt, err := node.Predict(ctx, cpuMilli*(j), node.GetStatus())
-				if err != nil {
-					lg.Warn().Msgf("node.Predict() for name=%s got error at wattMatrix[%d][%d] err=%v", node.Name, nodeIdx, j, err)
-					watt = math.Inf(1)
+			// the same status is used for the capacity and the prediction as it may be updated meanwhile
+			status := node.GetStatus()
+			nodeMaxWorkloads[nodeIdx] = maxWorkloads(status, cpuMilli, numWorkloads)
+			// no need to predict beyond the capacity, see applyMaxWorkloads
+			requests := make([]ResourceRequest, nodeMaxWorkloads[nodeIdx]+1)
+			for j := range requests {
+				requests[j] = request.Mul(j)
+			}
+			pred, err := node.PredictBatchDetails(ctx, requests, status)
+			if err != nil {
+				lg.Warn().Msgf("node.PredictBatch() for name=%s got error at wattMatrix[%d] err=%v", node.Name, nodeIdx, err)
+				for j := range requests {
+					wattMatrix[nodeIdx][j] = math.Inf(1)
+				}
+				return
+			}
+			lg.Debug().Msgf("call node.PredictBatch() for name=%s wattMatrix[%d] watts=%v lower=%v upper=%v source=%s",
+				node.Name, nodeIdx, pred.Watts, pred.Lower, pred.Upper, pred.Source)
+			copy(wattMatrix[nodeIdx], pred.Watts)
+			nodeSources[nodeIdx] = pred.Source
+			if pred.HasInterval() {
+				lowerMatrix[nodeIdx] = make([]float64, len(pred.Watts))
+				upperMatrix[nodeIdx] = make([]float64, len(pred.Watts))
+				for j := range pred.Watts {
+					lowerMatrix[nodeIdx][j] = pred.Lower[j] - pred.Watts[0]
+					upperMatrix[nodeIdx][j] = pred.Upper[j] - pred.Watts[0]
 				}
-				lg.Debug().Msgf("call node.Predict() for name=%s wattMatrix[%d][%d] watt=%f", node.Name, nodeIdx, j, watt)
-				wattMatrix[nodeIdx][j] = watt
 			}
 		}()
 		i++
@@ -72,12 +131,13 @@ func (e *Estimator) EstimatePowerConsumption(ctx context.Context, cpuMilli, numW
 	if err != nil {
 		return nil, err
 	}
-	lg.Debug().Msgf("wattDiffs=%v", wattDiffs)
-	minCosts, err := ComputeLeastCostsFn(e.Nodes.Len(), numWorkloads, wattDiffs)
+	applyMaxWorkloads(wattDiffs, nodeMaxWorkloads)
+	lg.Debug().Msgf("wattDiffs=%v nodeMaxWorkloads=%v", wattDiffs, nodeMaxWorkloads)
+	minCosts, minCostPatterns, approximate, err := ComputeLeastCostsFn(e.Nodes.Len(), numWorkloads, wattDiffs)
 	if err != nil {
 		return nil, err
 	}
-	lg.Debug().Msgf("minCosts=%v", minCosts)
+	lg.Debug().Msgf("minCosts=%v minCostPatterns=%v approximate=%v", minCosts, minCostPatterns, approximate)
 
 	// validate
 	for _, v := range minCosts {
@@ -85,7 +145,277 @@ func (e *Estimator) EstimatePowerConsumption(ctx context.Context, cpuMilli, numW
 			return nil, fmt.Errorf("-Inf or NaN detected %v (%w)", minCosts, ErrEstimator)
 		}
 	}
-	return minCosts, nil
+	est := &PowerConsumptionEstimate{
+		WattIncreases: minCosts,
+		Placements:    toPlacements(nodeNames, minCostPatterns),
+		Predictors:    toPredictors(nodeNames, nodeSources),
+		Approximate:   approximate,
+	}
+	if hasBounds(lowerMatrix) {
+		est.WattIncreasesLower = make([]float64, len(minCosts))
+		est.WattIncreasesUpper = make([]float64, len(minCosts))
+		for k, minCost := range minCosts {
+			est.WattIncreasesLower[k], est.WattIncreasesUpper[k] = minCost, minCost
+			if math.IsInf(minCost, 1) || len(minCostPatterns[k]) == 0 {
+				continue
+			}
+			est.WattIncreasesLower[k], est.WattIncreasesUpper[k] = sumBounds(minCostPatterns[k][0], func(i, n int) (float64, float64) {
+				if lowerMatrix[i] == nil {
+					d := wattMatrix[i][n] - wattMatrix[i][0]
+					return d, d
+				}
+				return lowerMatrix[i][n], upperMatrix[i][n]
+			})
+		}
+	}
+	return est, nil
+}
+
+// hasBounds returns true if any of the nodes has the bounds.
+func hasBounds(boundMatrix [][]float64) bool {
+	for _, row := range boundMatrix {
+		if row != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// sumBounds sums the bounds of the increases of the nodes with workloads in the placement pattern[node].
+func sumBounds(pattern []int, bounds func(i, n int) (lower, upper float64)) (lower, upper float64) {
+	for i, n := range pattern {
+		if n == 0 {
+			continue
+		}
+		l, u := bounds(i, n)
+		lower += l
+		upper += u
+	}
+	return lower, upper
+}
+
+// toPlacements converts patterns[k][p][nodeIdx] to placements[k][p][nodeName],
+// nodes with no workloads are omitted.
+func toPlacements(nodeNames []string, patterns [][][]int) [][]map[string]int {
+	placements := make([][]map[string]int, len(patterns))
+	for k, kPatterns := range patterns {
+		placements[k] = make([]map[string]int, len(kPatterns))
+		for p, pattern := range kPatterns {
+			placements[k][p] = map[string]int{}
+			for nodeIdx, n := range pattern {
+				if n > 0 {
+					placements[k][p][nodeNames[nodeIdx]] = n
+				}
+			}
+		}
+	}
+	return placements
+}
+
+// toPredictors maps node names to non-empty sources, returns nil if all the sources are empty.
+func toPredictors(nodeNames, nodeSources []string) map[string]string {
+	var predictors map[string]string
+	for i, source := range nodeSources {
+		if source == "" {
+			continue
+		}
+		if predictors == nil {
+			predictors = map[string]string{}
+		}
+		predictors[nodeNames[i]] = source
+	}
+	return predictors
+}
+
+// PowerConsumptionGroupsEstimate is the result of Estimator.EstimatePowerConsumptionGroups.
+type PowerConsumptionGroupsEstimate struct {
+	// WattIncrease is the least increase in power consumption when all the workloads are placed.
+	WattIncrease float64
+	// Placements holds the placements that achieve WattIncrease (ties included),
+	// each placement maps node names to the number of workloads of each group placed on them
+	// (nodes with no workloads are omitted). Placements is empty if WattIncrease is +Inf.
+	Placements []map[string][]int
+	// WattIncreaseLower and WattIncreaseUpper are the bounds of WattIncrease for Placements[0],
+	// both are nil unless any of the nodes reports the bounds, see PowerConsumptionEstimate.
+	WattIncreaseLower *float64
+	WattIncreaseUpper *float64
+	// Predictors maps node names to the predictors that produced their predictions, see PowerConsumptionEstimate.
+	Predictors map[string]string
+}
+
+// EstimatePowerConsumptionGroups is a thread-safe function that
+// estimates power consumption when all the given groups of workloads are placed.
+//
+// Each node predicts all the distinct total resources of the workloads placed on it in one PredictBatch call.
+// +Inf in the response represents errors in Node.GetStatus or PowerConsumptionPredictor.Predict,
+// or that the workloads do not fit in the nodes.
+func (e *Estimator) EstimatePowerConsumptionGroups(ctx context.Context, workloads []WorkloadGroup) (*PowerConsumptionGroupsEstimate, error) {
+	e.initOnce()
+
+	if e.Nodes.Len() == 0 {
+		return nil, fmt.Errorf("no nodes available (%w)", ErrEstimatorNoNodesAvailable)
+	}
+
+	counts := make([]int, len(workloads))
+	requests := make([]ResourceRequest, len(workloads))
+	for g, w := range workloads {
+		requests[g] = NewResourceRequest(w.CpuMilli, w.MemoryBytes, w.ExtendedResources)
+		if err := validateResourceRequest(requests[g]); err != nil {
+			return nil, fmt.Errorf("workloads[%d]: %w", g, err)
+		}
+		if w.Count < 0 {
+			return nil, fmt.Errorf("count must not be negative workloads[%d]=%+v (%w)", g, w, ErrEstimatorInvalidRequest)
+		}
+		counts[g] = w.Count
+	}
+	gs := newGroupStates(counts)
+	if total := e.Nodes.Len() * gs.transitions(); total > maxPowMN {
+		return nil, fmt.Errorf("too many workloads counts=%v nodes=%d (%w)", counts, e.Nodes.Len(), ErrEstimatorInvalidRequest)
+	}
+
+	// init wattMatrix[node][state]
+	lg.Debug().Msgf("init wattMatrix[%d][%d]", e.Nodes.Len(), gs.Len())
+	wattMatrix := make([][]float64, e.Nodes.Len())
+	for i := range wattMatrix {
+		wattMatrix[i] = make([]float64, gs.Len())
+	}
+
+	// prediction
+	wg := sync.WaitGroup{}
+	i := 0
+	nodeNames := make([]string, e.Nodes.Len())
+	nodeSources := make([]string, e.Nodes.Len())
+	// lowerMatrix[node][state] and upperMatrix[node][state] hold the bounds of the increases, nil if unknown
+	lowerMatrix := make([][]float64, e.Nodes.Len())
+	upperMatrix := make([][]float64, e.Nodes.Len())
+	e.Nodes.Range(func(nodeName string, node *Node) bool {
+		nodeIdx := i
+		nodeNames[nodeIdx] = nodeName
+		wg.Add(1)
+		// NOTE: no need to sync, the goroutines below only write different slice elements
+		go func() {
+			defer wg.Done()
+			// the same status is used for the capacity and the prediction as it may be updated meanwhile
+			status := node.GetStatus()
+			available, capacityKnown := availableCPUMilli(status)
+			// states with the same total resources share the prediction
+			stateToRequest := make([]int, gs.Len())
+			requestIdx := map[string]int{}
+			uniqueRequests := []ResourceRequest{}
+			for s := 0; s < gs.Len(); s++ {
+				var request ResourceRequest
+				for g, n := range gs.Decode(s) {
+					request = request.Add(requests[g].Mul(n))
+				}
+				if s != 0 && capacityKnown && request.CPUMilli > available {
+					// do not fit, see below
+					stateToRequest[s] = -1
+					continue
+				}
+				// NOTE: fmt prints maps sorted by key
+				key := fmt.Sprintf("%+v", request)
+				idx, ok := requestIdx[key]
+				if !ok {
+					idx = len(uniqueRequests)
+					requestIdx[key] = idx
+					uniqueRequests = append(uniqueRequests, request)
+				}
+				stateToRequest[s] = idx
+			}
+			pred, err := node.PredictBatchDetails(ctx, uniqueRequests, status)
+			if err != nil {
+				lg.Warn().Msgf("node.PredictBatch() for name=%s got error at wattMatrix[%d] err=%v", node.Name, nodeIdx, err)
+				pred = &BatchPrediction{}
+			} else {
+				nodeSources[nodeIdx] = pred.Source
+			}
+			lg.Debug().Msgf("call node.PredictBatch() for name=%s requests=%v watts=%v lower=%v upper=%v source=%s",
+				node.Name, uniqueRequests, pred.Watts, pred.Lower, pred.Upper, pred.Source)
+			if pred.HasInterval() {
+				lowerMatrix[nodeIdx] = make([]float64, gs.Len())
+				upperMatrix[nodeIdx] = make([]float64, gs.Len())
+			}
+			for s, idx := range stateToRequest {
+				switch {
+				case idx < 0:
+					wattMatrix[nodeIdx][s] = math.NaN()
+				case err != nil:
+					wattMatrix[nodeIdx][s] = math.Inf(1)
+				default:
+					wattMatrix[nodeIdx][s] = pred.Watts[idx]
+					if pred.HasInterval() {
+						// the state 0 is always the first request
+						lowerMatrix[nodeIdx][s] = pred.Lower[idx] - pred.Watts[0]
+						upperMatrix[nodeIdx][s] = pred.Upper[idx] - pred.Watts[0]
+					}
+				}
+			}
+		}()
+		i++
+		return true
+	})
+	wg.Wait()
+	lg.Debug().Msgf("wattMatrix=%v", wattMatrix)
+
+	// errors in a row -> [0 +Inf +Inf ...], then states that do not fit (NaN) -> +Inf
+	patchWattMatrix(wattMatrix)
+	for i := range wattMatrix {
+		w0 := wattMatrix[i][0]
+		for s := range wattMatrix[i] {
+			if math.IsNaN(wattMatrix[i][s]) {
+				wattMatrix[i][s] = math.Inf(1)
+				continue
+			}
+			wattMatrix[i][s] -= w0
+		}
+	}
+
+	// search
+	minCost, minCostPatterns, err := findLeastCostGroupsDP(e.Nodes.Len(), counts, wattMatrix)
+	if err != nil {
+		return nil, err
+	}
+	lg.Debug().Msgf("minCost=%v minCostPatterns=%v", minCost, minCostPatterns)
+
+	// validate
+	if math.IsInf(minCost, -1) || math.IsNaN(minCost) {
+		return nil, fmt.Errorf("-Inf or NaN detected %v (%w)", minCost, ErrEstimator)
+	}
+
+	placements := make([]map[string][]int, len(minCostPatterns))
+	for p, pattern := range minCostPatterns {
+		placements[p] = map[string][]int{}
+		for nodeIdx, x := range pattern {
+			for _, n := range x {
+				if n > 0 {
+					placements[p][nodeNames[nodeIdx]] = x
+					break
+				}
+			}
+		}
+	}
+	est := &PowerConsumptionGroupsEstimate{
+		WattIncrease: minCost,
+		Placements:   placements,
+		Predictors:   toPredictors(nodeNames, nodeSources),
+	}
+	if hasBounds(lowerMatrix) {
+		lower, upper := minCost, minCost
+		if !math.IsInf(minCost, 1) && len(minCostPatterns) != 0 {
+			states := make([]int, len(minCostPatterns[0]))
+			for nodeIdx, x := range minCostPatterns[0] {
+				states[nodeIdx] = gs.Encode(x)
+			}
+			lower, upper = sumBounds(states, func(i, s int) (float64, float64) {
+				if lowerMatrix[i] == nil {
+					return wattMatrix[i][s], wattMatrix[i][s]
+				}
+				return lowerMatrix[i][s], upperMatrix[i][s]
+			})
+		}
+		est.WattIncreaseLower, est.WattIncreaseUpper = &lower, &upper
+	}
+	return est, nil
 }
 
 func patchWattMatrix(wattMatrix [][]float64) {
@@ -116,6 +446,87 @@ func patchWattMatrix(wattMatrix [][]float64) {
 	}
 }
 
+// NewResourceRequest converts the optional fields in the API to ResourceRequest.
+func NewResourceRequest(cpuMilli int, memoryBytes *int64, extendedResources *map[string]int64) ResourceRequest {
+	r := ResourceRequest{CPUMilli: cpuMilli}
+	if memoryBytes != nil {
+		r.MemoryBytes = *memoryBytes
+	}
+	if extendedResources != nil {
+		r.ExtendedResources = *extendedResources
+	}
+	return r
+}
+
+func validateResourceRequest(r ResourceRequest) error {
+	if r.CPUMilli < 0 || r.MemoryBytes < 0 {
+		return fmt.Errorf("cpu_milli and memory_bytes must not be negative request=%+v (%w)", r, ErrEstimatorInvalidRequest)
+	}
+	for k, v := range r.ExtendedResources {
+		if v < 0 {
+			return fmt.Errorf("extended_resources[%s] must not be negative request=%+v (%w)", k, r, ErrEstimatorInvalidRequest)
+		}
+	}
+	return nil
+}
+
+// maxWorkloads returns the number of workloads that fit in the node,
+// i.e. (NodeStatusAllocatableCPUMilli - NodeStatusRequestedCPUMilli) / cpuMilli, capped by numWorkloads.
+// It returns numWorkloads if the node capacity is unknown.
+func maxWorkloads(status *NodeStatus, cpuMilli, numWorkloads int) int {
+	available, ok := availableCPUMilli(status)
+	if cpuMilli <= 0 || !ok {
+		return numWorkloads
+	}
+	n := available / cpuMilli
+	if n < 0 {
+		return 0
+	}
+	if n > numWorkloads {
+		return numWorkloads
+	}
+	return n
+}
+
+// availableCPUMilli returns NodeStatusAllocatableCPUMilli - NodeStatusRequestedCPUMilli,
+// ok is false if the node capacity is unknown.
+func availableCPUMilli(status *NodeStatus) (cpuMilli int, ok bool) {
+	if status == nil {
+		return 0, false
+	}
+	allocatable, err := status.GetInt(NodeStatusAllocatableCPUMilli)
+	if err != nil {
+		return 0, false
+	}
+	requested, err := status.GetInt(NodeStatusRequestedCPUMilli)
+	if err != nil {
+		requested = 0
+	}
+	return allocatable - requested, true
+}
+
+// applyMaxWorkloads sets +Inf to wattDiffs[i][j] if j+1 workloads do not fit in the node i,
+// so the solver treats such placements as infeasible.
+func applyMaxWorkloads(wattDiffs [][]float64, nodeMaxWorkloads []int) {
+	for i := range wattDiffs {
+		for j := nodeMaxWorkloads[i]; j < len(wattDiffs[i]); j++ {
+			wattDiffs[i][j] = math.Inf(1)
+		}
+	}
+}
+
+// Ready returns true if any of the nodes has a PowerConsumptionPredictor.
+func (e *Estimator) Ready() bool {
+	e.initOnce()
+
+	ready := false
+	e.Nodes.Range(func(_ string, n *Node) bool {
+		ready = n.pcPredictor != nil
+		return !ready
+	})
+	return ready
+}
+
 func (e *Estimator) stop() {
 	e.initOnce()
 
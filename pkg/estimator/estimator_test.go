@@ -1,8 +1,12 @@
 package estimator
 
 import (
+	"context"
+	"errors"
 	"math"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestEstimators_Len(t *testing.T) {
@@ -106,3 +110,160 @@ func Test_patchWattMatrix(t *testing.T) {
 		})
 	}
 }
+
+func Test_maxWorkloads(t *testing.T) {
+	newStatus := func(allocatable, requested int) *NodeStatus {
+		s := NewNodeStatus()
+		if allocatable >= 0 {
+			s.SetInt(NodeStatusAllocatableCPUMilli, allocatable)
+		}
+		if requested >= 0 {
+			s.SetInt(NodeStatusRequestedCPUMilli, requested)
+		}
+		return s
+	}
+	type args struct {
+		status       *NodeStatus
+		cpuMilli     int
+		numWorkloads int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{"unknown", args{newStatus(-1, -1), 500, 5}, 5},
+		{"nil", args{nil, 500, 5}, 5},
+		{"cpu_0", args{newStatus(4000, 0), 0, 5}, 5},
+		{"no_requested", args{newStatus(4000, -1), 2000, 5}, 2},
+		{"requested", args{newStatus(4000, 1500), 500, 8}, 5},
+		{"capped", args{newStatus(4000, 1500), 500, 3}, 3},
+		{"full", args{newStatus(4000, 3800), 500, 5}, 0},
+		{"overcommitted", args{newStatus(4000, 4500), 500, 5}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maxWorkloads(tt.args.status, tt.args.cpuMilli, tt.args.numWorkloads); got != tt.want {
+				t.Errorf("maxWorkloads() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applyMaxWorkloads(t *testing.T) {
+	inf := math.Inf(1)
+	wattDiffs := [][]float64{
+		{1, 2, 3},
+		{1, 2, 3},
+		{1, 2, 3},
+	}
+	applyMaxWorkloads(wattDiffs, []int{3, 1, 0})
+	want := [][]float64{
+		{1, 2, 3},
+		{1, inf, inf},
+		{inf, inf, inf},
+	}
+	if !reflect.DeepEqual(wattDiffs, want) {
+		t.Errorf("want=%v but got=%v", want, wattDiffs)
+	}
+}
+
+func TestEstimator_EstimatePowerConsumptionResources(t *testing.T) {
+	// n0: 100mCPU/W, memory is free
+	// n1: 200mCPU/W + 1GiB/W
+	est := &Estimator{Nodes: &Nodes{}}
+	est.Nodes.Add("n0", NewNode("n0", nil, time.Second, &FakePCPredictor{
+		PredictFunc: func(_ context.Context, requestCPUMilli int, _ *NodeStatus) (float64, error) {
+			return float64(requestCPUMilli) / 100, nil
+		},
+	}))
+	est.Nodes.Add("n1", NewNode("n1", nil, time.Second, &FakePCPredictor{
+		PredictResourcesFunc: func(_ context.Context, r ResourceRequest, _ *NodeStatus) (float64, error) {
+			return float64(r.CPUMilli)/200 + float64(r.MemoryBytes)/(1<<30), nil
+		},
+	}))
+	defer est.stop()
+
+	tests := []struct {
+		name    string
+		request ResourceRequest
+		want    *PowerConsumptionEstimate
+		wantErr error
+	}{
+		{"cpu", ResourceRequest{CPUMilli: 1000}, &PowerConsumptionEstimate{
+			WattIncreases: []float64{5, 10},
+			Placements:    [][]map[string]int{{{"n1": 1}}, {{"n1": 2}}},
+		}, nil},
+		{"memory", ResourceRequest{CPUMilli: 1000, MemoryBytes: 10 << 30}, &PowerConsumptionEstimate{
+			WattIncreases: []float64{10, 20},
+			Placements:    [][]map[string]int{{{"n0": 1}}, {{"n0": 2}}},
+		}, nil},
+		{"negative", ResourceRequest{CPUMilli: 1000, MemoryBytes: -1}, nil, ErrEstimatorInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := est.EstimatePowerConsumptionResources(context.Background(), tt.request, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Estimator.EstimatePowerConsumptionResources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Estimator.EstimatePowerConsumptionResources() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimator_EstimatePowerConsumption_approximate(t *testing.T) {
+	est := &Estimator{Nodes: &Nodes{}}
+	est.Nodes.Add("n0", NewNode("n0", nil, time.Second, &FakePCPredictor{PredictFunc: func(_ context.Context, requestCPUMilli int, _ *NodeStatus) (float64, error) {
+		return float64(requestCPUMilli) / 100, nil
+	}}))
+	defer est.stop()
+
+	defer func(fn ComputeLeastCostsFunc) { ComputeLeastCostsFn = fn }(ComputeLeastCostsFn)
+	for _, approximate := range []bool{false, true} {
+		ComputeLeastCostsFn = func(clusterNum, podNum int, wattMatrix [][]float64) ([]float64, [][][]int, bool, error) {
+			minCosts, patterns, _, err := findLeastCostsDP(clusterNum, podNum, wattMatrix)
+			return minCosts, patterns, approximate, err
+		}
+		got, err := est.EstimatePowerConsumption(context.Background(), 1000, 2)
+		if err != nil || got.Approximate != approximate {
+			t.Errorf("Estimator.EstimatePowerConsumption() = %+v, %v, want Approximate=%v", got, err, approximate)
+		}
+	}
+}
+
+func TestEstimator_Ready(t *testing.T) {
+	est := &Estimator{}
+	defer est.stop()
+	if est.Ready() {
+		t.Errorf("Estimator.Ready() = true, want false")
+	}
+	est.Nodes.Add("n0", NewNode("n0", nil, time.Second, nil))
+	if est.Ready() {
+		t.Errorf("Estimator.Ready() = true, want false")
+	}
+	est.Nodes.Add("n1", NewNode("n1", nil, time.Second, testPCPredictorV1{}))
+	if !est.Ready() {
+		t.Errorf("Estimator.Ready() = false, want true")
+	}
+}
+
+func TestEstimator_EstimatePowerConsumption_batch(t *testing.T) {
+	p := &testBatchPCPredictor{}
+	est := &Estimator{Nodes: &Nodes{}}
+	est.Nodes.Add("n0", NewNode("n0", nil, time.Second, p))
+	defer est.stop()
+
+	got, err := est.EstimatePowerConsumption(context.Background(), 500, 20)
+	if err != nil {
+		t.Fatalf("Estimator.EstimatePowerConsumption() error = %v", err)
+	}
+	if len(got.WattIncreases) != 20 || got.WattIncreases[19] != 100 {
+		t.Errorf("Estimator.EstimatePowerConsumption() = %v", got.WattIncreases)
+	}
+	if p.calls != 1 {
+		t.Errorf("PredictBatch() calls = %v, want 1", p.calls)
+	}
+}
@@ -0,0 +1,210 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	HTTPClientDefaultRetryBackoff = 100 * time.Millisecond
+
+	CircuitBreakerDefaultFailureThreshold = 5
+	CircuitBreakerDefaultOpenDuration     = 30 * time.Second
+
+	// httpClientDrainLimit is the maximum number of bytes read from response bodies before closing them,
+	// so that connections are reused without reading large bodies.
+	httpClientDrainLimit = 4 << 10
+)
+
+// HTTPClient sends HTTP requests for the remote NodeMonitors and PowerConsumptionPredictors
+// with a timeout for each attempt, bounded retries with exponential backoff and a circuit breaker.
+//
+// A nil *HTTPClient sends requests with http.DefaultClient once.
+type HTTPClient struct {
+	// Client sends the requests, http.DefaultClient is used if nil.
+	Client *http.Client
+	// Timeout limits each attempt including reading the response body if positive.
+	Timeout time.Duration
+	// MaxRetries is the number of retries after connection errors and 5xx responses.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled for each retry,
+	// defaults to HTTPClientDefaultRetryBackoff.
+	RetryBackoff time.Duration
+	// CircuitBreaker fails requests fast after repeated failures if not nil.
+	CircuitBreaker *CircuitBreaker
+}
+
+// Do sends the request and returns the last response or error.
+// Callers must close the response body, preferably with DrainAndClose.
+//
+// Requests with a body are retried only if req.GetBody is set (e.g. created by http.NewRequest with a bytes.Buffer).
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if c == nil {
+		return http.DefaultClient.Do(req)
+	}
+	if c.CircuitBreaker != nil {
+		if err := c.CircuitBreaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	backoff := c.RetryBackoff
+	if backoff <= 0 {
+		backoff = HTTPClientDefaultRetryBackoff
+	}
+	ctx := req.Context()
+	for i := 0; ; i++ {
+		resp, err := c.do(req)
+		retryable := err != nil || resp.StatusCode >= 500
+		if !retryable || i >= c.MaxRetries || ctx.Err() != nil || (req.Body != nil && req.GetBody == nil) {
+			if c.CircuitBreaker != nil {
+				switch {
+				case retryable && ctx.Err() != nil:
+					// the caller gave up, which says nothing about the server
+					c.CircuitBreaker.cancel()
+				case retryable:
+					c.CircuitBreaker.failure()
+				default:
+					c.CircuitBreaker.success()
+				}
+			}
+			return resp, err
+		}
+
+		if err != nil {
+			lg.Debug().Msgf("HTTPClient.Do retrying url=%v attempt=%d err=%v", req.URL, i+1, err)
+		} else {
+			lg.Debug().Msgf("HTTPClient.Do retrying url=%v attempt=%d status=%v", req.URL, i+1, resp.Status)
+			DrainAndClose(resp.Body)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("unable to rewind the request body: %w", err)
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// do sends the request once with Timeout.
+func (c *HTTPClient) do(req *http.Request) (*http.Response, error) {
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if c.Timeout <= 0 {
+		return client.Do(req)
+	}
+	ctx, cncl := context.WithTimeout(req.Context(), c.Timeout)
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		cncl()
+		return nil, err
+	}
+	// the timeout also applies to reading the body, so cancel the context when the body is closed
+	resp.Body = &cancelOnCloseReader{ReadCloser: resp.Body, cncl: cncl}
+	return resp, nil
+}
+
+type cancelOnCloseReader struct {
+	io.ReadCloser
+	cncl context.CancelFunc
+}
+
+func (r *cancelOnCloseReader) Close() error {
+	defer r.cncl()
+	return r.ReadCloser.Close()
+}
+
+// DrainAndClose reads the rest of the body (up to a few KiB) and closes it,
+// so that the connection can be reused.
+func DrainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, httpClientDrainLimit))
+	_ = body.Close()
+}
+
+// CircuitBreaker opens after FailureThreshold consecutive failures and fails requests fast with ErrCircuitOpen,
+// after OpenDuration one request is allowed as a trial (half-open) and the circuit closes if it succeeds.
+// Requests canceled by the callers (e.g. the deadline of the estimation) are not counted as failures.
+type CircuitBreaker struct {
+	// FailureThreshold defaults to CircuitBreakerDefaultFailureThreshold.
+	FailureThreshold int
+	// OpenDuration defaults to CircuitBreakerDefaultOpenDuration.
+	OpenDuration time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	// trial is true while the trial request in the half-open state is in flight
+	trial bool
+}
+
+func (cb *CircuitBreaker) failureThreshold() int {
+	if cb.FailureThreshold <= 0 {
+		return CircuitBreakerDefaultFailureThreshold
+	}
+	return cb.FailureThreshold
+}
+
+func (cb *CircuitBreaker) openDuration() time.Duration {
+	if cb.OpenDuration <= 0 {
+		return CircuitBreakerDefaultOpenDuration
+	}
+	return cb.OpenDuration
+}
+
+// Open returns true if requests are failed fast.
+func (cb *CircuitBreaker) Open() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.failures >= cb.failureThreshold() && (cb.trial || time.Since(cb.openedAt) < cb.openDuration())
+}
+
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.failures < cb.failureThreshold() {
+		return nil
+	}
+	if cb.trial || time.Since(cb.openedAt) < cb.openDuration() {
+		return fmt.Errorf("%d consecutive failures (%w)", cb.failures, ErrCircuitOpen)
+	}
+	cb.trial = true
+	return nil
+}
+
+func (cb *CircuitBreaker) success() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures = 0
+	cb.trial = false
+}
+
+// cancel ends the trial request without counting a failure.
+func (cb *CircuitBreaker) cancel() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.trial = false
+}
+
+func (cb *CircuitBreaker) failure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures++
+	cb.trial = false
+	if cb.failures >= cb.failureThreshold() {
+		cb.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,162 @@
+package estimator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestHTTPServer returns a server that responds with statuses[i] to the i-th request (the last one after that),
+// the bodies of the requests are checked against wantBody.
+func newTestHTTPServer(t *testing.T, statuses []int, wantBody string, delay time.Duration) (*httptest.Server, *int32) {
+	var requests int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&requests, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != wantBody {
+			t.Errorf("request body = %q, want %q", body, wantBody)
+		}
+		time.Sleep(delay)
+		w.WriteHeader(statuses[i])
+		w.Write([]byte("hoge"))
+	}))
+	t.Cleanup(sv.Close)
+	return sv, &requests
+}
+
+func TestHTTPClient_Do(t *testing.T) {
+	tests := []struct {
+		name         string
+		client       *HTTPClient
+		statuses     []int
+		delay        time.Duration
+		wantStatus   int
+		wantRequests int32
+		wantErr      bool
+	}{
+		{"nil", nil, []int{500, 200}, 0, 500, 1, false},
+		{"no_retries", &HTTPClient{}, []int{500, 200}, 0, 500, 1, false},
+		{"retry_5xx", &HTTPClient{MaxRetries: 2, RetryBackoff: time.Millisecond}, []int{500, 503, 200}, 0, 200, 3, false},
+		{"retries_exhausted", &HTTPClient{MaxRetries: 2, RetryBackoff: time.Millisecond}, []int{500}, 0, 500, 3, false},
+		{"no_retry_4xx", &HTTPClient{MaxRetries: 2, RetryBackoff: time.Millisecond}, []int{404, 200}, 0, 404, 1, false},
+		{"timeout", &HTTPClient{Timeout: 10 * time.Millisecond, MaxRetries: 1, RetryBackoff: time.Millisecond}, []int{200}, 100 * time.Millisecond, 0, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sv, requests := newTestHTTPServer(t, tt.statuses, "fuga", tt.delay)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, sv.URL, bytes.NewBufferString("fuga"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := tt.client.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HTTPClient.Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				body, err := io.ReadAll(resp.Body)
+				DrainAndClose(resp.Body)
+				if err != nil || string(body) != "hoge" {
+					t.Errorf("HTTPClient.Do() body = %q, %v, want hoge", body, err)
+				}
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("HTTPClient.Do() status = %v, want %v", resp.StatusCode, tt.wantStatus)
+				}
+			}
+			if got := atomic.LoadInt32(requests); got != tt.wantRequests {
+				t.Errorf("HTTPClient.Do() sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_Do_circuitBreaker(t *testing.T) {
+	statuses := []int{500, 500, 500, 200}
+	sv, requests := newTestHTTPServer(t, statuses, "", 0)
+	cb := &CircuitBreaker{FailureThreshold: 2, OpenDuration: 50 * time.Millisecond}
+	c := &HTTPClient{CircuitBreaker: cb}
+	do := func() (int, error) {
+		req, _ := http.NewRequest(http.MethodGet, sv.URL, nil)
+		resp, err := c.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		DrainAndClose(resp.Body)
+		return resp.StatusCode, nil
+	}
+
+	// 2 failures open the circuit
+	for i := 0; i < 2; i++ {
+		if status, err := do(); err != nil || status != 500 {
+			t.Fatalf("HTTPClient.Do() = %v, %v, want 500", status, err)
+		}
+	}
+	if !cb.Open() {
+		t.Errorf("CircuitBreaker.Open() = false, want true")
+	}
+	if _, err := do(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("HTTPClient.Do() error = %v, want ErrCircuitOpen", err)
+	}
+	if got := atomic.LoadInt32(requests); got != 2 {
+		t.Errorf("HTTPClient.Do() sent %d requests while open, want 2", got)
+	}
+
+	// the trial request fails so the circuit opens again
+	time.Sleep(60 * time.Millisecond)
+	if status, err := do(); err != nil || status != 500 {
+		t.Fatalf("HTTPClient.Do() = %v, %v, want 500", status, err)
+	}
+	if _, err := do(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("HTTPClient.Do() error = %v, want ErrCircuitOpen", err)
+	}
+
+	// the trial request succeeds so the circuit closes
+	time.Sleep(60 * time.Millisecond)
+	if status, err := do(); err != nil || status != 200 {
+		t.Fatalf("HTTPClient.Do() = %v, %v, want 200", status, err)
+	}
+	if cb.Open() {
+		t.Errorf("CircuitBreaker.Open() = true, want false")
+	}
+	if status, err := do(); err != nil || status != 200 {
+		t.Errorf("HTTPClient.Do() = %v, %v, want 200", status, err)
+	}
+}
+
+func TestHTTPClient_Do_circuitBreakerCanceled(t *testing.T) {
+	sv, _ := newTestHTTPServer(t, []int{200}, "", 100*time.Millisecond)
+	cb := &CircuitBreaker{FailureThreshold: 1}
+	c := &HTTPClient{CircuitBreaker: cb}
+
+	// requests canceled by the callers are not counted as failures
+	for i := 0; i < 2; i++ {
+		ctx, cncl := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, sv.URL, nil)
+		_, err := c.Do(req)
+		cncl()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("HTTPClient.Do() error = %v, want context.DeadlineExceeded", err)
+		}
+	}
+	if cb.Open() {
+		t.Errorf("CircuitBreaker.Open() = true, want false")
+	}
+
+	// the per-attempt timeout is a failure of the server
+	c.Timeout = 10 * time.Millisecond
+	req, _ := http.NewRequest(http.MethodGet, sv.URL, nil)
+	if _, err := c.Do(req); err == nil {
+		t.Fatalf("HTTPClient.Do() error = nil, want a timeout")
+	}
+	if !cb.Open() {
+		t.Errorf("CircuitBreaker.Open() = false, want true")
+	}
+}
@@ -0,0 +1,8 @@
+// Package inference is the generated code of the KServe V2 inference protocol over gRPC (grpc_predict_v2.proto),
+// which is served by MLServer, Triton and KServe.
+//
+// grpc_predict_v2.proto is copied from https://github.com/kserve/kserve/blob/v0.10.0/docs/predict-api/v2/grpc_predict_v2.proto
+// with go_package added.
+//
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.15.1 generate
+package inference
@@ -2,27 +2,59 @@ package estimator
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// NodeStatusDefaultMaxAgeIntervals is the default max age of the NodeStatus values in refresh intervals.
+const NodeStatusDefaultMaxAgeIntervals = 3
+
 type Node struct {
 	Name string
+	// NodeStatusMaxAges overrides the max ages of the NodeStatus values per key (0 means unlimited),
+	// the default is NodeStatusDefaultMaxAgeIntervals times the refresh interval. Set it before Nodes.Add.
+	NodeStatusMaxAges map[NodeStatusKey]time.Duration
+	// NodeStatusHistorySize is the number of NodeStatus snapshots kept, NodeStatusHistoryDefaultSize if not positive.
+	// At least the largest window of NodeStatusAggregations is kept. Set it before Nodes.Add.
+	NodeStatusHistorySize int
+	// NodeStatusAggregations smooth the values per key over the history before they are passed to the predictor.
+	// Set it before Nodes.Add.
+	NodeStatusAggregations map[NodeStatusKey]NodeStatusAggregation
 
 	mu     sync.Mutex
 	stopCh chan struct{}
 
 	monitors   []NodeMonitor
 	nmInterval time.Duration
-	status     *NodeStatus
+	// status is the aggregated latest snapshot in history
+	status  *NodeStatus
+	history *nodeStatusRing
+	// errs holds the last error of each source
+	errs map[string]NodeError
 
 	pcPredictor PowerConsumptionPredictor
 }
 
+// NodeError is the last error of a NodeMonitor or the PowerConsumptionPredictor of a Node.
+type NodeError struct {
+	// Source is the name of the NodeMonitor or the PowerConsumptionPredictor.
+	Source    string
+	Message   string
+	Timestamp time.Time
+}
+
 var _ NodeMonitor = (*Node)(nil)
 var _ PowerConsumptionPredictor = (*Node)(nil)
+var _ PowerConsumptionPredictorV2 = (*Node)(nil)
+var _ BatchPowerConsumptionPredictor = (*Node)(nil)
+var _ DetailedPCPredictor = (*Node)(nil)
 
+// FetchStatus sets the values fetched by the monitors to base with the monitor names as their sources.
 func (n *Node) FetchStatus(ctx context.Context, base *NodeStatus) error {
 	if base == nil {
 		base = NewNodeStatus()
@@ -32,19 +64,115 @@ func (n *Node) FetchStatus(ctx context.Context, base *NodeStatus) error {
 			lg.Warn().Msgf("FetchStatus failed as NodeMonitor[%d] is nil", i)
 			continue
 		}
-		err := nm.FetchStatus(ctx, base)
+		status := NewNodeStatus()
+		err := nm.FetchStatus(ctx, status)
 		if err != nil {
 			lg.Warn().Msgf("FetchStatus failed NodeMonitor[%d] err=%v", i, err)
+			n.recordError(nodeMonitorName(nm), err)
 		}
+		// values set before the error are still valid
+		base.merge(status, nodeMonitorName(nm))
 	}
 	return nil
 }
 
+// nodeMonitorName returns the type name of the NodeMonitor, e.g. "IPMIExporterNodeMonitor".
+func nodeMonitorName(nm NodeMonitor) string {
+	return typeName(nm)
+}
+
+// typeName returns the type name of v without the package and the pointer.
+func typeName(v any) string {
+	name := fmt.Sprintf("%T", v)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
 func (n *Node) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
 	if n.pcPredictor == nil {
 		return 0.0, ErrPCPredictorNotFound
 	}
-	return n.pcPredictor.Predict(ctx, requestCPUMilli, status)
+	watt, err = n.pcPredictor.Predict(ctx, requestCPUMilli, status)
+	n.recordPredictorError(err)
+	return watt, err
+}
+
+// PredictResources uses PowerConsumptionPredictorV2 if the predictor implements it,
+// otherwise only the CPU request is passed to PowerConsumptionPredictor.
+func (n *Node) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	if n.pcPredictor == nil {
+		return 0.0, ErrPCPredictorNotFound
+	}
+	watt, err = ToPCPredictorV2(n.pcPredictor).PredictResources(ctx, request, status)
+	n.recordPredictorError(err)
+	return watt, err
+}
+
+// PredictBatch uses BatchPowerConsumptionPredictor if the predictor implements it,
+// otherwise the requests are predicted one by one.
+func (n *Node) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	if n.pcPredictor == nil {
+		return nil, ErrPCPredictorNotFound
+	}
+	watts, err = PredictBatch(ctx, n.pcPredictor, requests, status)
+	n.recordPredictorError(err)
+	return watts, err
+}
+
+// PredictBatchDetails is PredictBatch that also returns the bounds of the predictions and the name of the predictor
+// if the predictor implements DetailedPCPredictor (e.g. FallbackPCPredictor, ErrorBoundsPCPredictor).
+func (n *Node) PredictBatchDetails(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error) {
+	if n.pcPredictor == nil {
+		return nil, ErrPCPredictorNotFound
+	}
+	pred, err := PredictBatchDetails(ctx, n.pcPredictor, requests, status)
+	n.recordPredictorError(err)
+	return pred, err
+}
+
+// MonitorNames returns the names of the NodeMonitors, e.g. "IPMIExporterNodeMonitor".
+func (n *Node) MonitorNames() []string {
+	names := make([]string, len(n.monitors))
+	for i, nm := range n.monitors {
+		if nm != nil {
+			names[i] = nodeMonitorName(nm)
+		}
+	}
+	return names
+}
+
+// PredictorName returns the type name of the PowerConsumptionPredictor, e.g. "MLServerPCPredictor", empty if not set.
+func (n *Node) PredictorName() string {
+	if n.pcPredictor == nil {
+		return ""
+	}
+	return typeName(n.pcPredictor)
+}
+
+// LastErrors returns the last error of each NodeMonitor and the PowerConsumptionPredictor ordered by the sources.
+func (n *Node) LastErrors() []NodeError {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	errs := make([]NodeError, 0, len(n.errs))
+	for _, e := range n.errs {
+		errs = append(errs, e)
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Source < errs[j].Source })
+	return errs
+}
+
+func (n *Node) recordPredictorError(err error) {
+	if err != nil {
+		n.recordError(n.PredictorName(), err)
+	}
+}
+
+func (n *Node) recordError(source string, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.errs == nil {
+		n.errs = map[string]NodeError{}
+	}
+	n.errs[source] = NodeError{Source: source, Message: err.Error(), Timestamp: time.Now()}
 }
 
 func NewNode(name string, nms []NodeMonitor, nodeStatusRefreshInterval time.Duration, pcp PowerConsumptionPredictor) *Node {
@@ -56,24 +184,38 @@ func NewNode(name string, nms []NodeMonitor, nodeStatusRefreshInterval time.Dura
 		nmInterval:  nodeStatusRefreshInterval,
 	}
 	lg.Info().Msgf("NewNode() n=%+v", &n)
+	if missing := missingNodeStatusKeys(nms, pcp); len(missing) != 0 {
+		lg.Warn().Msgf("NewNode() name=%s the PowerConsumptionPredictor consumes %v not produced by the NodeMonitors", name, missing)
+	}
 	return &n
 }
 
+// missingNodeStatusKeys returns the keys consumed by the predictor but not produced by any of the monitors,
+// it returns nil if any of the monitors does not implement NodeStatusProducer as what they produce is unknown.
+func missingNodeStatusKeys(nms []NodeMonitor, pcp PowerConsumptionPredictor) []NodeStatusKey {
+	produced := map[NodeStatusKey]struct{}{}
+	for _, nm := range nms {
+		p, ok := nm.(NodeStatusProducer)
+		if !ok {
+			return nil
+		}
+		for _, k := range p.ProducedNodeStatusKeys() {
+			produced[k] = struct{}{}
+		}
+	}
+	var missing []NodeStatusKey
+	for _, k := range consumedNodeStatusKeys(pcp) {
+		if _, ok := produced[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
 func (n *Node) start() {
 	lg.Info().Msgf("Node.start() Name=%v", n.Name)
 
-	updateStatus := func() {
-		timeout := n.nmInterval / 2
-		ctx, cncl := context.WithTimeout(context.Background(), timeout)
-		status := NewNodeStatus()
-		_ = n.FetchStatus(ctx, status) // this does not return errors
-		cncl()
-		n.mu.Lock()
-		n.status = status
-		n.mu.Unlock()
-	}
-
-	updateStatus() // first time exec
+	n.updateStatus() // first time exec
 
 	go func() {
 		for {
@@ -81,15 +223,55 @@ func (n *Node) start() {
 			case <-n.stopCh:
 				return
 			case <-time.After(n.nmInterval):
-				updateStatus()
+				n.updateStatus()
 			}
 		}
 	}()
 }
 
+func (n *Node) updateStatus() {
+	timeout := n.nmInterval / 2
+	ctx, cncl := context.WithTimeout(context.Background(), timeout)
+	status := n.newNodeStatus()
+	_ = n.FetchStatus(ctx, status) // this does not return errors
+	cncl()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.history == nil {
+		n.history = newNodeStatusRing(n.historySize())
+	}
+	// keep the last-known-good values of failed monitors until they become stale
+	status.keepLastKnownGood(n.history.latest())
+	n.history.add(status)
+	if len(n.NodeStatusAggregations) == 0 {
+		n.status = status
+		return
+	}
+	n.status = aggregateNodeStatus(n.history.snapshots(), n.NodeStatusAggregations)
+}
+
+func (n *Node) historySize() int {
+	size := n.NodeStatusHistorySize
+	if size <= 0 {
+		size = NodeStatusHistoryDefaultSize
+	}
+	for _, agg := range n.NodeStatusAggregations {
+		if agg.Window > size {
+			size = agg.Window
+		}
+	}
+	return size
+}
+
 func (n *Node) stop() {
 	lg.Info().Msgf("Node.stop() Name=%v", n.Name)
 	close(n.stopCh)
+	// release connections held by the predictor (e.g. MLServerGRPCPCPredictor)
+	if c, ok := n.pcPredictor.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			lg.Warn().Msgf("Node.stop() Name=%v could not close PowerConsumptionPredictor err=%v", n.Name, err)
+		}
+	}
 }
 
 // GetStatus returns current status of the Node,
@@ -98,11 +280,29 @@ func (n *Node) GetStatus() *NodeStatus {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	if n.status == nil {
-		return NewNodeStatus()
+		return n.newNodeStatus()
 	}
 	return n.status
 }
 
+// GetStatusHistory returns the raw (not aggregated) NodeStatus snapshots ordered from the oldest.
+func (n *Node) GetStatusHistory() []*NodeStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.history == nil {
+		return nil
+	}
+	return n.history.snapshots()
+}
+
+// newNodeStatus returns an empty NodeStatus with the max ages of the Node.
+func (n *Node) newNodeStatus() *NodeStatus {
+	s := NewNodeStatus()
+	s.maxAges = n.NodeStatusMaxAges
+	s.defaultMaxAge = NodeStatusDefaultMaxAgeIntervals * n.nmInterval
+	return s
+}
+
 type Nodes struct {
 	c int32
 	m sync.Map
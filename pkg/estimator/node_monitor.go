@@ -21,14 +21,3 @@ func (m *FakeNodeMonitor) FetchStatus(ctx context.Context, base *NodeStatus) err
 	}
 	return m.FetchFunc(ctx, base)
 }
-
-type RedfishNodeMonitor struct {
-	// TODO
-}
-
-var _ NodeMonitor = (*RedfishNodeMonitor)(nil)
-
-func (m *RedfishNodeMonitor) FetchStatus(ctx context.Context, base *NodeStatus) error {
-	// TODO
-	return fmt.Errorf("not yet implemented (%w)", ErrNodeMonitor)
-}
@@ -14,9 +14,12 @@ import (
 type DifferentialPressureNodeMonitor struct {
 	Server string
 	Sensor string
+	// HTTPClient sends the requests, http.DefaultClient is used if nil.
+	HTTPClient *HTTPClient
 }
 
 var _ NodeMonitor = (*DifferentialPressureNodeMonitor)(nil)
+var _ NodeStatusProducer = (*DifferentialPressureNodeMonitor)(nil)
 
 // NewDifferentialPressureNodeMonitorFromURL parses the given endpoint URL.
 //
@@ -37,6 +40,10 @@ func NewDifferentialPressureNodeMonitorFromURL(endpoint string) (*DifferentialPr
 	}, nil
 }
 
+func (m *DifferentialPressureNodeMonitor) ProducedNodeStatusKeys() []NodeStatusKey {
+	return []NodeStatusKey{NodeStatusStaticPressureDiff}
+}
+
 func (m *DifferentialPressureNodeMonitor) FetchStatus(ctx context.Context, base *NodeStatus) error {
 	if base == nil {
 		base = NewNodeStatus()
@@ -45,7 +52,9 @@ func (m *DifferentialPressureNodeMonitor) FetchStatus(ctx context.Context, base
 	if err != nil {
 		return fmt.Errorf("could not read sensor value (%w): %v", ErrNodeMonitor, err)
 	}
-	NodeStatusSetStaticPressureDiff(base, v.Pressure)
+	if err := base.SetFloat(NodeStatusStaticPressureDiff, v.Pressure); err != nil {
+		return fmt.Errorf("invalid sensor value (%w): %v", ErrNodeMonitor, err)
+	}
 	return nil
 }
 
@@ -75,10 +84,11 @@ func (m *DifferentialPressureNodeMonitor) GETValueRequest(ctx context.Context) (
 		lg.Trace().Msgf("DifferentialPressureNodeMonitor.FetchStatus request=%v", curl.String())
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.HTTPClient.Do(req)
 	if err != nil {
 		return v, fmt.Errorf("unable to send HTTP request: %w", err)
 	}
+	defer DrainAndClose(resp.Body)
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var apiResp differentialPressureAPIResponse
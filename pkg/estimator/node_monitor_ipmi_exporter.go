@@ -0,0 +1,165 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"moul.io/http2curl/v2"
+)
+
+const (
+	ipmiExporterMetricTemperature      = "ipmi_temperature_celsius"
+	ipmiExporterMetricPowerConsumption = "ipmi_dcmi_power_consumption_watts"
+
+	// DefaultIPMIExporterInletTempSensor matches common names of inlet temperature sensors
+	// e.g. "Inlet Temp", "System Board Inlet Temp", "Ambient Temp"
+	DefaultIPMIExporterInletTempSensor = `(?i)inlet|ambient`
+)
+
+type IPMIExporterNodeMonitor struct {
+	// Endpoint specifies the metrics endpoint of the exporter
+	// e.g. "http://localhost:9290/metrics", "http://localhost:9290/ipmi?target=10.0.0.1"
+	Endpoint string
+	// InletTempSensor is matched against the "name" label of ipmi_temperature_celsius
+	// to choose the inlet temperature sensor.
+	InletTempSensor *regexp.Regexp
+	// HTTPClient sends the requests, http.DefaultClient is used if nil.
+	HTTPClient *HTTPClient
+}
+
+var _ NodeMonitor = (*IPMIExporterNodeMonitor)(nil)
+var _ NodeStatusProducer = (*IPMIExporterNodeMonitor)(nil)
+
+// NewIPMIExporterNodeMonitorFromURL parses the given endpoint URL and the inlet temperature sensor pattern,
+// DefaultIPMIExporterInletTempSensor is used if inletTempSensor is empty.
+//
+// Format: {Server}/**
+// Example: http://hogehoge:9290/metrics -> &{Endpoint: "http://hogehoge:9290/metrics"}
+func NewIPMIExporterNodeMonitorFromURL(endpoint, inletTempSensor string) (*IPMIExporterNodeMonitor, error) {
+	parsedURL, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse IPMIExporter endpoint URL %w: %v", ErrNodeMonitor, err)
+	}
+	if parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return nil, fmt.Errorf("could not parse IPMIExporter endpoint URL %w: url must be in {Server}/** format", ErrNodeMonitor)
+	}
+	if inletTempSensor == "" {
+		inletTempSensor = DefaultIPMIExporterInletTempSensor
+	}
+	re, err := regexp.Compile(inletTempSensor)
+	if err != nil {
+		return nil, fmt.Errorf("could not compile inlet temp sensor pattern %w: %v", ErrNodeMonitor, err)
+	}
+	return &IPMIExporterNodeMonitor{
+		Endpoint:        parsedURL.String(),
+		InletTempSensor: re,
+	}, nil
+}
+
+func (m *IPMIExporterNodeMonitor) ProducedNodeStatusKeys() []NodeStatusKey {
+	return []NodeStatusKey{NodeStatusAmbientTemp, NodeStatusPowerConsumption}
+}
+
+// FetchStatus scrapes the exporter and sets the inlet temperature and the DCMI power consumption.
+func (m *IPMIExporterNodeMonitor) FetchStatus(ctx context.Context, base *NodeStatus) error {
+	if base == nil {
+		base = NewNodeStatus()
+	}
+	mfs, err := m.GETMetricsRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("could not scrape metrics (%w): %v", ErrNodeMonitor, err)
+	}
+
+	var inletTempFound, powerFound bool
+	if v, ok := m.inletTemp(mfs); ok {
+		if err := base.SetFloat(NodeStatusAmbientTemp, v); err != nil {
+			return fmt.Errorf("invalid inlet temperature (%w): %v", ErrNodeMonitor, err)
+		}
+		inletTempFound = true
+	}
+	if v, ok := gaugeValue(mfs[ipmiExporterMetricPowerConsumption], nil); ok {
+		if err := base.SetFloat(NodeStatusPowerConsumption, v); err != nil {
+			return fmt.Errorf("invalid power consumption (%w): %v", ErrNodeMonitor, err)
+		}
+		powerFound = true
+	}
+
+	if !inletTempFound && !powerFound {
+		return fmt.Errorf("neither %s{name=~%q} nor %s found (%w)", ipmiExporterMetricTemperature, m.InletTempSensor, ipmiExporterMetricPowerConsumption, ErrNodeMonitor)
+	}
+	return nil
+}
+
+func (m *IPMIExporterNodeMonitor) inletTemp(mfs map[string]*dto.MetricFamily) (float64, bool) {
+	re := m.InletTempSensor
+	if re == nil {
+		re = regexp.MustCompile(DefaultIPMIExporterInletTempSensor)
+	}
+	return gaugeValue(mfs[ipmiExporterMetricTemperature], func(metric *dto.Metric) bool {
+		for _, l := range metric.GetLabel() {
+			if l.GetName() == "name" && re.MatchString(l.GetValue()) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// gaugeValue returns the value of the first metric in the family that passed the given filter,
+// all metrics pass if filter is nil. Untyped metrics are treated as gauges.
+func gaugeValue(mf *dto.MetricFamily, filter func(*dto.Metric) bool) (float64, bool) {
+	if mf == nil {
+		return 0.0, false
+	}
+	for _, metric := range mf.GetMetric() {
+		if filter != nil && !filter(metric) {
+			continue
+		}
+		switch mf.GetType() {
+		case dto.MetricType_GAUGE:
+			return metric.GetGauge().GetValue(), true
+		case dto.MetricType_UNTYPED:
+			return metric.GetUntyped().GetValue(), true
+		default:
+			return 0.0, false
+		}
+	}
+	return 0.0, false
+}
+
+func (m *IPMIExporterNodeMonitor) GETMetricsRequest(ctx context.Context) (map[string]*dto.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.Endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP request: %w", err)
+	}
+	req.Header.Set("Accept", string(expfmt.FmtText))
+
+	curl, err := http2curl.GetCurlCommand(req)
+	if err != nil {
+		lg.Err(err).Msgf("IPMIExporterNodeMonitor.FetchStatus could not parse http.Request to curl command")
+	} else {
+		lg.Trace().Msgf("IPMIExporterNodeMonitor.FetchStatus request=%v", curl.String())
+	}
+
+	resp, err := m.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send HTTP request: %w", err)
+	}
+	defer DrainAndClose(resp.Body)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var parser expfmt.TextParser
+		mfs, err := parser.TextToMetricFamilies(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse resp: %w", err)
+		}
+		return mfs, nil
+	default:
+		return nil, fmt.Errorf("HTTP status=%v url=%v", resp.Status, m.Endpoint)
+	}
+}
@@ -0,0 +1,120 @@
+package estimator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+const (
+	testIPMIExporterMetrics1 = `# HELP ipmi_dcmi_power_consumption_watts Current power consumption in Watts.
+# TYPE ipmi_dcmi_power_consumption_watts gauge
+ipmi_dcmi_power_consumption_watts 152
+# HELP ipmi_temperature_celsius Temperature reading in degree Celsius.
+# TYPE ipmi_temperature_celsius gauge
+ipmi_temperature_celsius{id="1",name="CPU1 Temp"} 48
+ipmi_temperature_celsius{id="4",name="Inlet Temp"} 22.5
+ipmi_temperature_celsius{id="5",name="Exhaust Temp"} 31
+# HELP ipmi_up '1' if a scrape of the IPMI device was successful, '0' otherwise.
+# TYPE ipmi_up gauge
+ipmi_up{collector="dcmi"} 1
+ipmi_up{collector="ipmi"} 1
+`
+	testIPMIExporterMetrics2 = `ipmi_temperature_celsius{id="1",name="CPU1 Temp"} 48
+ipmi_temperature_celsius{id="2",name="Ambient"} 24
+`
+	testIPMIExporterMetrics3 = `# TYPE ipmi_up gauge
+ipmi_up{collector="ipmi"} 0
+`
+)
+
+func TestIPMIExporterNodeMonitor_FetchStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		metrics         string
+		inletTempSensor string
+		wantAmbient     float64
+		wantAmbientOK   bool
+		wantPower       float64
+		wantPowerOK     bool
+		wantErr         bool
+	}{
+		{"default", testIPMIExporterMetrics1, "", 22.5, true, 152, true, false},
+		{"exhaust", testIPMIExporterMetrics1, "^Exhaust Temp$", 31, true, 152, true, false},
+		{"no_match", testIPMIExporterMetrics1, "^Outlet", 0, false, 152, true, false},
+		{"untyped_no_power", testIPMIExporterMetrics2, "", 24, true, 0, false, false},
+		{"no_metrics", testIPMIExporterMetrics3, "", 0, false, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.metrics))
+			}))
+			defer sv.Close()
+
+			m, err := NewIPMIExporterNodeMonitorFromURL(sv.URL+"/metrics", tt.inletTempSensor)
+			if err != nil {
+				t.Errorf("NewIPMIExporterNodeMonitorFromURL() error = %v", err)
+				return
+			}
+			status := NewNodeStatus()
+			err = m.FetchStatus(context.Background(), status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IPMIExporterNodeMonitor.FetchStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			at, err := status.GetFloat(NodeStatusAmbientTemp)
+			if (err == nil) != tt.wantAmbientOK || (tt.wantAmbientOK && at != tt.wantAmbient) {
+				t.Errorf("NodeStatusGetAmbientTemp() = %v, %v, want %v", at, err, tt.wantAmbient)
+			}
+			pc, err := status.GetFloat(NodeStatusPowerConsumption)
+			if (err == nil) != tt.wantPowerOK || (tt.wantPowerOK && pc != tt.wantPower) {
+				t.Errorf("NodeStatusGetPowerConsumption() = %v, %v, want %v", pc, err, tt.wantPower)
+			}
+		})
+	}
+}
+
+func TestNewIPMIExporterNodeMonitorFromURL(t *testing.T) {
+	type args struct {
+		endpoint        string
+		inletTempSensor string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *IPMIExporterNodeMonitor
+		wantErr bool
+	}{
+		{"normal", args{"http://localhost:9290/metrics", ""}, &IPMIExporterNodeMonitor{
+			Endpoint:        "http://localhost:9290/metrics",
+			InletTempSensor: regexp.MustCompile(DefaultIPMIExporterInletTempSensor),
+		}, false},
+		{"remote", args{"http://localhost:9290/ipmi?target=10.0.0.1&module=default", "^Inlet Temp$"}, &IPMIExporterNodeMonitor{
+			Endpoint:        "http://localhost:9290/ipmi?target=10.0.0.1&module=default",
+			InletTempSensor: regexp.MustCompile("^Inlet Temp$"),
+		}, false},
+		{"no_scheme", args{"localhost:9290/metrics", ""}, nil, true},
+		{"wrong_pattern", args{"http://localhost:9290/metrics", "(Inlet"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewIPMIExporterNodeMonitorFromURL(tt.args.endpoint, tt.args.inletTempSensor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewIPMIExporterNodeMonitorFromURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("NewIPMIExporterNodeMonitorFromURL() = %v, want nil", got)
+				}
+				return
+			}
+			if got.Endpoint != tt.want.Endpoint || got.InletTempSensor.String() != tt.want.InletTempSensor.String() {
+				t.Errorf("NewIPMIExporterNodeMonitorFromURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
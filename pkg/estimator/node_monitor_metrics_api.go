@@ -0,0 +1,113 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MetricsAPINodeMonitor fetches NodeStatusCPUUsage from the Kubernetes Metrics API (metrics.k8s.io),
+// NodeStatusLogicalProcessors and NodeStatusAllocatableCPUMilli from the Node resource,
+// and NodeStatusRequestedCPUMilli from the Pods running on the node.
+//
+// The scheme of the Client must contain corev1 and metricsv1beta1.
+// NOTE: Use an uncached client (e.g. manager.GetAPIReader()) as metrics.k8s.io does not support watch.
+type MetricsAPINodeMonitor struct {
+	Client   client.Reader
+	NodeName string
+}
+
+var _ NodeMonitor = (*MetricsAPINodeMonitor)(nil)
+var _ NodeStatusProducer = (*MetricsAPINodeMonitor)(nil)
+
+func (m *MetricsAPINodeMonitor) ProducedNodeStatusKeys() []NodeStatusKey {
+	return []NodeStatusKey{NodeStatusLogicalProcessors, NodeStatusAllocatableCPUMilli, NodeStatusRequestedCPUMilli, NodeStatusCPUUsage}
+}
+
+func (m *MetricsAPINodeMonitor) FetchStatus(ctx context.Context, base *NodeStatus) error {
+	if base == nil {
+		base = NewNodeStatus()
+	}
+	if m.Client == nil {
+		return fmt.Errorf("client not set (%w)", ErrNodeMonitor)
+	}
+
+	var node corev1.Node
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: m.NodeName}, &node); err != nil {
+		return fmt.Errorf("could not get node=%s (%w): %v", m.NodeName, ErrNodeMonitor, err)
+	}
+	// capacity is the number of logical processors, use allocatable only if capacity is not reported
+	cpu, ok := node.Status.Capacity[corev1.ResourceCPU]
+	if !ok || cpu.IsZero() {
+		cpu, ok = node.Status.Allocatable[corev1.ResourceCPU]
+	}
+	if !ok || cpu.IsZero() {
+		return fmt.Errorf("cpu capacity not found in node=%s (%w)", m.NodeName, ErrNodeMonitor)
+	}
+	if err := base.SetInt(NodeStatusLogicalProcessors, int(cpu.Value())); err != nil {
+		return fmt.Errorf("invalid cpu capacity in node=%s (%w): %v", m.NodeName, ErrNodeMonitor, err)
+	}
+	if allocatable, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+		if err := base.SetInt(NodeStatusAllocatableCPUMilli, int(allocatable.MilliValue())); err != nil {
+			return fmt.Errorf("invalid allocatable cpu in node=%s (%w): %v", m.NodeName, ErrNodeMonitor, err)
+		}
+	}
+
+	requested, err := m.requestedCPUMilli(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list pods on node=%s (%w): %v", m.NodeName, ErrNodeMonitor, err)
+	}
+	if err := base.SetInt(NodeStatusRequestedCPUMilli, requested); err != nil {
+		return fmt.Errorf("invalid cpu requests on node=%s (%w): %v", m.NodeName, ErrNodeMonitor, err)
+	}
+
+	var nodeMetrics metricsv1beta1.NodeMetrics
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: m.NodeName}, &nodeMetrics); err != nil {
+		return fmt.Errorf("could not get node metrics=%s (%w): %v", m.NodeName, ErrNodeMonitor, err)
+	}
+	usage, ok := nodeMetrics.Usage[corev1.ResourceCPU]
+	if !ok {
+		return fmt.Errorf("cpu usage not found in node metrics=%s (%w)", m.NodeName, ErrNodeMonitor)
+	}
+	if err := base.SetFloat(NodeStatusCPUUsage, float64(usage.MilliValue())/float64(cpu.MilliValue())); err != nil {
+		return fmt.Errorf("invalid cpu usage in node metrics=%s (%w): %v", m.NodeName, ErrNodeMonitor, err)
+	}
+
+	return nil
+}
+
+// requestedCPUMilli sums up the CPU requests of the non-terminated pods on the node
+// in the same way as the scheduler does, i.e. max(sum(containers), max(initContainers)) + overhead.
+func (m *MetricsAPINodeMonitor) requestedCPUMilli(ctx context.Context) (int, error) {
+	var pods corev1.PodList
+	if err := m.Client.List(ctx, &pods, client.MatchingFields{"spec.nodeName": m.NodeName}); err != nil {
+		return 0, err
+	}
+	var sum int64
+	for _, pod := range pods.Items {
+		// NOTE: some clients (e.g. fake) ignore field selectors
+		if pod.Spec.NodeName != m.NodeName {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		var containers, initContainers int64
+		for _, c := range pod.Spec.Containers {
+			containers += c.Resources.Requests.Cpu().MilliValue()
+		}
+		for _, c := range pod.Spec.InitContainers {
+			if v := c.Resources.Requests.Cpu().MilliValue(); v > initContainers {
+				initContainers = v
+			}
+		}
+		if initContainers > containers {
+			containers = initContainers
+		}
+		sum += containers + pod.Spec.Overhead.Cpu().MilliValue()
+	}
+	return int(sum), nil
+}
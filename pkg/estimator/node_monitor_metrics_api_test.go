@@ -0,0 +1,182 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestMetricsAPIClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := metricsv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newTestNode(name string, capacity, allocatable string) *corev1.Node {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if capacity != "" {
+		node.Status.Capacity = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(capacity)}
+	}
+	if allocatable != "" {
+		node.Status.Allocatable = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(allocatable)}
+	}
+	return node
+}
+
+func newTestNodeMetrics(name string, usage string) *metricsv1beta1.NodeMetrics {
+	return &metricsv1beta1.NodeMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Usage:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(usage)},
+	}
+}
+
+func TestMetricsAPINodeMonitor_FetchStatus(t *testing.T) {
+	tests := []struct {
+		name                  string
+		objs                  []client.Object
+		nodeName              string
+		wantCPUUsage          float64
+		wantLogicalProcessors int
+		wantErr               bool
+	}{
+		{"ok", []client.Object{newTestNode("n0", "8", "7500m"), newTestNodeMetrics("n0", "2")}, "n0", 0.25, 8, false},
+		{"ok_milli", []client.Object{newTestNode("n0", "4", ""), newTestNodeMetrics("n0", "500m")}, "n0", 0.125, 4, false},
+		{"ok_allocatable", []client.Object{newTestNode("n0", "", "16"), newTestNodeMetrics("n0", "4")}, "n0", 0.25, 16, false},
+		{"no_node", []client.Object{newTestNodeMetrics("n0", "2")}, "n0", 0, 0, true},
+		{"no_capacity", []client.Object{newTestNode("n0", "", ""), newTestNodeMetrics("n0", "2")}, "n0", 0, 0, true},
+		{"no_metrics", []client.Object{newTestNode("n0", "8", "8")}, "n0", 0, 8, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MetricsAPINodeMonitor{
+				Client:   newTestMetricsAPIClient(t, tt.objs...),
+				NodeName: tt.nodeName,
+			}
+			status := NewNodeStatus()
+			err := m.FetchStatus(context.Background(), status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MetricsAPINodeMonitor.FetchStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantLogicalProcessors != 0 {
+				got, err := status.GetInt(NodeStatusLogicalProcessors)
+				if err != nil || got != tt.wantLogicalProcessors {
+					t.Errorf("NodeStatusGetLogicalProcessors() = %v, %v, want %v", got, err, tt.wantLogicalProcessors)
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := status.GetFloat(NodeStatusCPUUsage)
+			if err != nil || got != tt.wantCPUUsage {
+				t.Errorf("NodeStatusGetCPUUsage() = %v, %v, want %v", got, err, tt.wantCPUUsage)
+			}
+		})
+	}
+}
+
+func newTestPod(name, nodeName string, phase corev1.PodPhase, containers, initContainers []string) *corev1.Pod {
+	toContainers := func(cpus []string) []corev1.Container {
+		var cs []corev1.Container
+		for i, cpu := range cpus {
+			cs = append(cs, corev1.Container{
+				Name:      fmt.Sprintf("c%d", i),
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+			})
+		}
+		return cs
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: corev1.PodSpec{
+			NodeName:       nodeName,
+			Containers:     toContainers(containers),
+			InitContainers: toContainers(initContainers),
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestMetricsAPINodeMonitor_FetchStatus_capacity(t *testing.T) {
+	tests := []struct {
+		name            string
+		objs            []client.Object
+		wantAllocatable int
+		wantRequested   int
+	}{
+		{"no_pods", []client.Object{newTestNode("n0", "8", "7500m"), newTestNodeMetrics("n0", "2")}, 7500, 0},
+		{"pods", []client.Object{newTestNode("n0", "8", "7500m"), newTestNodeMetrics("n0", "2"),
+			newTestPod("p0", "n0", corev1.PodRunning, []string{"500m", "250m"}, nil),
+			newTestPod("p1", "n0", corev1.PodPending, []string{"100m"}, []string{"1"}),
+			newTestPod("p2", "n0", corev1.PodSucceeded, []string{"2"}, nil),
+			newTestPod("p3", "n1", corev1.PodRunning, []string{"2"}, nil),
+		}, 7500, 1750},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MetricsAPINodeMonitor{
+				Client:   newTestMetricsAPIClient(t, tt.objs...),
+				NodeName: "n0",
+			}
+			status := NewNodeStatus()
+			if err := m.FetchStatus(context.Background(), status); err != nil {
+				t.Errorf("MetricsAPINodeMonitor.FetchStatus() error = %v", err)
+				return
+			}
+			gotAllocatable, err := status.GetInt(NodeStatusAllocatableCPUMilli)
+			if err != nil || gotAllocatable != tt.wantAllocatable {
+				t.Errorf("NodeStatusGetAllocatableCPUMilli() = %v, %v, want %v", gotAllocatable, err, tt.wantAllocatable)
+			}
+			gotRequested, err := status.GetInt(NodeStatusRequestedCPUMilli)
+			if err != nil || gotRequested != tt.wantRequested {
+				t.Errorf("NodeStatusGetRequestedCPUMilli() = %v, %v, want %v", gotRequested, err, tt.wantRequested)
+			}
+		})
+	}
+}
+
+func TestMetricsAPINodeMonitor_FetchStatus_MLServerPCPredictor(t *testing.T) {
+	var requests int32
+	sv := newTestMLServer(t, &requests)
+	defer sv.Close()
+
+	// 1 of 4 CPUs is used
+	m := &MetricsAPINodeMonitor{
+		Client:   newTestMetricsAPIClient(t, newTestNode("n0", "4", "4"), newTestNodeMetrics("n0", "1")),
+		NodeName: "n0",
+	}
+	status := NewNodeStatus()
+	if err := m.FetchStatus(context.Background(), status); err != nil {
+		t.Fatalf("MetricsAPINodeMonitor.FetchStatus() error = %v", err)
+	}
+
+	p, err := NewMLServerPCPredictorFromURL(sv.URL + "/v2/models/model1/versions/v0.1.0/infer")
+	if err != nil {
+		t.Fatalf("NewMLServerPCPredictorFromURL() error = %v", err)
+	}
+	p.Features = []NodeStatusKey{NodeStatusCPUUsage}
+
+	// requesting 1 more CPU doubles cpuUsage, 2 more CPUs triple it
+	got, err := p.PredictBatch(context.Background(), []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 1000}, {CPUMilli: 2000}}, status)
+	if err != nil {
+		t.Fatalf("MLServerPCPredictor.PredictBatch() error = %v", err)
+	}
+	if want := []float64{0.25, 0.5, 0.75}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MLServerPCPredictor.PredictBatch() = %v, want %v", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package estimator
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -10,99 +11,424 @@ import (
 
 type NodeStatusKey string
 
+// NodeStatusValueType is the type of NodeStatusValue.
+type NodeStatusValueType int
+
+const (
+	NodeStatusValueTypeFloat NodeStatusValueType = iota
+	NodeStatusValueTypeInt
+	NodeStatusValueTypeDuration
+	NodeStatusValueTypeBool
+)
+
+func (t NodeStatusValueType) String() string {
+	switch t {
+	case NodeStatusValueTypeFloat:
+		return "float"
+	case NodeStatusValueTypeInt:
+		return "int"
+	case NodeStatusValueTypeDuration:
+		return "duration"
+	case NodeStatusValueTypeBool:
+		return "bool"
+	default:
+		return fmt.Sprintf("NodeStatusValueType(%d)", int(t))
+	}
+}
+
+// NodeStatusValue is a typed value in NodeStatus, use NodeStatusFloat, NodeStatusInt, NodeStatusDuration or NodeStatusBool to create one.
+type NodeStatusValue struct {
+	Type NodeStatusValueType
+	f    float64 // float
+	i    int64   // int, duration in nanoseconds and bool (0 or 1)
+}
+
+func NodeStatusFloat(v float64) NodeStatusValue {
+	return NodeStatusValue{Type: NodeStatusValueTypeFloat, f: v}
+}
+
+func NodeStatusInt(v int64) NodeStatusValue {
+	return NodeStatusValue{Type: NodeStatusValueTypeInt, i: v}
+}
+
+func NodeStatusDuration(v time.Duration) NodeStatusValue {
+	return NodeStatusValue{Type: NodeStatusValueTypeDuration, i: int64(v)}
+}
+
+func NodeStatusBool(v bool) NodeStatusValue {
+	if v {
+		return NodeStatusValue{Type: NodeStatusValueTypeBool, i: 1}
+	}
+	return NodeStatusValue{Type: NodeStatusValueTypeBool}
+}
+
+// Float returns the value as float64 so that predictors can use values of any type as features,
+// durations are converted to seconds and bools to 0 or 1.
+func (v NodeStatusValue) Float() float64 {
+	switch v.Type {
+	case NodeStatusValueTypeFloat:
+		return v.f
+	case NodeStatusValueTypeDuration:
+		return time.Duration(v.i).Seconds()
+	default:
+		return float64(v.i)
+	}
+}
+
+// Int returns the value of NodeStatusValueTypeInt, 0 for the other types.
+func (v NodeStatusValue) Int() int64 {
+	if v.Type != NodeStatusValueTypeInt {
+		return 0
+	}
+	return v.i
+}
+
+// Duration returns the value of NodeStatusValueTypeDuration, 0 for the other types.
+func (v NodeStatusValue) Duration() time.Duration {
+	if v.Type != NodeStatusValueTypeDuration {
+		return 0
+	}
+	return time.Duration(v.i)
+}
+
+// Bool returns the value of NodeStatusValueTypeBool, false for the other types.
+func (v NodeStatusValue) Bool() bool {
+	return v.Type == NodeStatusValueTypeBool && v.i != 0
+}
+
+func (v NodeStatusValue) String() string {
+	switch v.Type {
+	case NodeStatusValueTypeFloat:
+		return strconv.FormatFloat(v.f, 'f', -1, 64)
+	case NodeStatusValueTypeDuration:
+		return time.Duration(v.i).String()
+	case NodeStatusValueTypeBool:
+		return strconv.FormatBool(v.i != 0)
+	default:
+		return strconv.FormatInt(v.i, 10)
+	}
+}
+
+// NodeStatusEntry is a value in NodeStatus with when and by which NodeMonitor it was set.
+type NodeStatusEntry struct {
+	Value     NodeStatusValue
+	Timestamp time.Time
+	// Source is the name of the NodeMonitor that set the value, empty if unknown.
+	Source string
+}
+
 type NodeStatus struct {
 	timestamp time.Time
-	data      sync.Map
+
+	mu   sync.RWMutex
+	data map[NodeStatusKey]NodeStatusEntry
+
+	// maxAges and defaultMaxAge limit the ages of the values if positive, see NodeStatus.Stale
+	maxAges       map[NodeStatusKey]time.Duration
+	defaultMaxAge time.Duration
 }
 
-func NewNodeStatus() *NodeStatus { return &NodeStatus{timestamp: time.Now()} }
+func NewNodeStatus() *NodeStatus {
+	return &NodeStatus{timestamp: time.Now(), data: map[NodeStatusKey]NodeStatusEntry{}}
+}
 
 func (s *NodeStatus) Timestamp() time.Time { return s.timestamp }
 
-func (s *NodeStatus) Get(k NodeStatusKey) (string, bool) {
-	v, ok := s.data.Load(k)
-	if !ok {
-		return "", false
+// Get returns the value even if it is stale, use the typed getters (e.g. GetFloat) to reject stale values.
+func (s *NodeStatus) Get(k NodeStatusKey) (NodeStatusValue, bool) {
+	e, ok := s.Entry(k)
+	return e.Value, ok
+}
+
+func (s *NodeStatus) Entry(k NodeStatusKey) (NodeStatusEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.data[k]
+	return e, ok
+}
+
+// Entries returns a snapshot of the entries.
+func (s *NodeStatus) Entries() map[NodeStatusKey]NodeStatusEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make(map[NodeStatusKey]NodeStatusEntry, len(s.data))
+	for k, e := range s.data {
+		entries[k] = e
+	}
+	return entries
+}
+
+// Set validates the value with the registered NodeStatusKeyInfo if any, invalid values are not stored.
+// The value is timestamped with the current time.
+func (s *NodeStatus) Set(k NodeStatusKey, v NodeStatusValue) error {
+	if info, ok := LookupNodeStatusKey(k); ok {
+		if err := info.Validate(v); err != nil {
+			return err
+		}
+	} else if v.Type == NodeStatusValueTypeFloat && (math.IsNaN(v.f) || math.IsInf(v.f, 0)) {
+		return fmt.Errorf("key=%v value=%v is not finite (%w)", k, v, ErrNodeStatus)
 	}
-	return v.(string), true
+	s.setEntry(k, NodeStatusEntry{Value: v, Timestamp: time.Now()})
+	return nil
 }
 
-func (s *NodeStatus) Set(k NodeStatusKey, v string) { s.data.Store(k, v) }
+func (s *NodeStatus) setEntry(k NodeStatusKey, e NodeStatusEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		s.data = map[NodeStatusKey]NodeStatusEntry{}
+	}
+	s.data[k] = e
+}
 
-func (s *NodeStatus) Delete(k NodeStatusKey) { s.data.Delete(k) }
+func (s *NodeStatus) Delete(k NodeStatusKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, k)
+}
 
-func (s *NodeStatus) Range(f func(k NodeStatusKey, v string) bool) {
-	s.data.Range(func(kk any, vv any) bool { return f(kk.(NodeStatusKey), vv.(string)) })
+// Range calls f for a snapshot of the values (including stale ones) in no particular order, so f may modify the NodeStatus.
+func (s *NodeStatus) Range(f func(k NodeStatusKey, v NodeStatusValue) bool) {
+	for k, e := range s.Entries() {
+		if !f(k, e.Value) {
+			return
+		}
+	}
 }
 
-const NodeStatusCPUUsage NodeStatusKey = "cpuUsage"
+// MaxAge returns how long the value of the key is used after it was set, 0 if unlimited.
+func (s *NodeStatus) MaxAge(k NodeStatusKey) time.Duration {
+	if d, ok := s.maxAges[k]; ok {
+		return d
+	}
+	return s.defaultMaxAge
+}
 
-func NodeStatusSetCPUUsage(s *NodeStatus, cpuUsage float64) {
-	s.Set(NodeStatusCPUUsage, strconv.FormatFloat(cpuUsage, 'f', -1, 64))
+// Stale returns true if the value of the key is older than MaxAge,
+// e.g. the NodeMonitor that sets it has been failing and the last-known-good value is kept.
+func (s *NodeStatus) Stale(k NodeStatusKey) bool {
+	e, ok := s.Entry(k)
+	return ok && s.stale(k, e)
 }
 
-func NodeStatusGetCPUUsage(s *NodeStatus) (float64, error) {
-	v, ok := s.Get(NodeStatusCPUUsage)
-	if !ok {
-		return -math.MaxFloat64, fmt.Errorf("key=%+v not found", NodeStatusCPUUsage)
+func (s *NodeStatus) stale(k NodeStatusKey, e NodeStatusEntry) bool {
+	maxAge := s.MaxAge(k)
+	return maxAge > 0 && time.Since(e.Timestamp) > maxAge
+}
+
+// merge copies the entries in src with the given source.
+func (s *NodeStatus) merge(src *NodeStatus, source string) {
+	for k, e := range src.Entries() {
+		e.Source = source
+		s.setEntry(k, e)
 	}
-	vv, err := strconv.ParseFloat(v, 64)
-	if err != nil {
-		return -math.MaxFloat64, err
+}
+
+// keepLastKnownGood copies the entries in prev that are not in s, so that values are kept until they become stale
+// when NodeMonitors fail transiently. Stale entries are dropped, e.g. the keys of removed or failing NodeMonitors.
+func (s *NodeStatus) keepLastKnownGood(prev *NodeStatus) {
+	if prev == nil {
+		return
+	}
+	for k, e := range prev.Entries() {
+		if _, ok := s.Entry(k); ok || s.stale(k, e) {
+			continue
+		}
+		s.setEntry(k, e)
 	}
-	return vv, nil
 }
 
-const NodeStatusAmbientTemp NodeStatusKey = "ambientTemp"
+func (s *NodeStatus) SetFloat(k NodeStatusKey, v float64) error { return s.Set(k, NodeStatusFloat(v)) }
+
+func (s *NodeStatus) SetInt(k NodeStatusKey, v int) error { return s.Set(k, NodeStatusInt(int64(v))) }
 
-func NodeStatusSetAmbientTemp(s *NodeStatus, ambientTemp float64) {
-	s.Set(NodeStatusAmbientTemp, strconv.FormatFloat(ambientTemp, 'f', -1, 64))
+func (s *NodeStatus) SetDuration(k NodeStatusKey, v time.Duration) error {
+	return s.Set(k, NodeStatusDuration(v))
 }
 
-func NodeStatusGetAmbientTemp(s *NodeStatus) (float64, error) {
-	v, ok := s.Get(NodeStatusAmbientTemp)
-	if !ok {
-		return -math.MaxFloat64, fmt.Errorf("key=%+v not found", NodeStatusAmbientTemp)
+func (s *NodeStatus) SetBool(k NodeStatusKey, v bool) error { return s.Set(k, NodeStatusBool(v)) }
+
+// GetFloat returns the value of any type as float64, see NodeStatusValue.Float.
+func (s *NodeStatus) GetFloat(k NodeStatusKey) (float64, error) {
+	v, err := s.get(k)
+	if err != nil {
+		return 0.0, err
 	}
-	vv, err := strconv.ParseFloat(v, 64)
+	return v.Float(), nil
+}
+
+func (s *NodeStatus) GetInt(k NodeStatusKey) (int, error) {
+	v, err := s.getType(k, NodeStatusValueTypeInt)
 	if err != nil {
-		return -math.MaxFloat64, err
+		return 0, err
 	}
-	return vv, nil
+	return int(v.Int()), nil
 }
 
-const NodeStatusStaticPressureDiff NodeStatusKey = "staticPressureDiff"
+func (s *NodeStatus) GetDuration(k NodeStatusKey) (time.Duration, error) {
+	v, err := s.getType(k, NodeStatusValueTypeDuration)
+	if err != nil {
+		return 0, err
+	}
+	return v.Duration(), nil
+}
 
-func NodeStatusSetStaticPressureDiff(s *NodeStatus, spd float64) {
-	s.Set(NodeStatusStaticPressureDiff, strconv.FormatFloat(spd, 'f', -1, 64))
+func (s *NodeStatus) GetBool(k NodeStatusKey) (bool, error) {
+	v, err := s.getType(k, NodeStatusValueTypeBool)
+	if err != nil {
+		return false, err
+	}
+	return v.Bool(), nil
 }
 
-func NodeStatusGetStaticPressureDiff(s *NodeStatus) (float64, error) {
-	v, ok := s.Get(NodeStatusStaticPressureDiff)
+func (s *NodeStatus) get(k NodeStatusKey) (NodeStatusValue, error) {
+	e, ok := s.Entry(k)
 	if !ok {
-		return -math.MaxFloat64, fmt.Errorf("key=%+v not found", NodeStatusStaticPressureDiff)
+		return e.Value, fmt.Errorf("key=%v not found (%w)", k, ErrNodeStatus)
+	}
+	if s.stale(k, e) {
+		return e.Value, fmt.Errorf("key=%v set at %v by %q is older than %v (%w)",
+			k, e.Timestamp.Format(time.RFC3339), e.Source, s.MaxAge(k), ErrNodeStatusStale)
 	}
-	vv, err := strconv.ParseFloat(v, 64)
+	return e.Value, nil
+}
+
+func (s *NodeStatus) getType(k NodeStatusKey, t NodeStatusValueType) (NodeStatusValue, error) {
+	v, err := s.get(k)
 	if err != nil {
-		return -math.MaxFloat64, err
+		return v, err
+	}
+	if v.Type != t {
+		return v, fmt.Errorf("key=%v value=%v is %v, not %v (%w)", k, v, v.Type, t, ErrNodeStatus)
 	}
-	return vv, nil
+	return v, nil
 }
 
-const NodeStatusLogicalProcessors NodeStatusKey = "logicalProcessors"
+// NodeStatusKeyInfo describes a NodeStatus key, NodeStatus.Set validates the values of registered keys.
+type NodeStatusKeyInfo struct {
+	Key  NodeStatusKey
+	Type NodeStatusValueType
+	// Unit of the values, e.g. "W", empty if dimensionless.
+	Unit        string
+	Description string
+	// NonNegative rejects negative values.
+	NonNegative bool
+}
 
-func NodeStatusSetLogicalProcessors(s *NodeStatus, num int) {
-	s.Set(NodeStatusLogicalProcessors, strconv.Itoa(num))
+// Validate returns an error if the value does not match the type of the key,
+// or is NaN, ±Inf or negative (if NonNegative).
+func (info NodeStatusKeyInfo) Validate(v NodeStatusValue) error {
+	if v.Type != info.Type {
+		return fmt.Errorf("key=%v value=%v is %v, not %v (%w)", info.Key, v, v.Type, info.Type, ErrNodeStatus)
+	}
+	if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("key=%v value=%v is not finite (%w)", info.Key, v, ErrNodeStatus)
+	}
+	if info.NonNegative && v.Float() < 0 {
+		return fmt.Errorf("key=%v value=%v must not be negative (%w)", info.Key, v, ErrNodeStatus)
+	}
+	return nil
+}
+
+var nodeStatusKeys sync.Map // map[NodeStatusKey]NodeStatusKeyInfo
+
+// RegisterNodeStatusKey registers the key so that NodeStatus.Set validates its values,
+// registering the same key with a different type returns an error.
+func RegisterNodeStatusKey(info NodeStatusKeyInfo) error {
+	if info.Key == "" {
+		return fmt.Errorf("key must not be empty (%w)", ErrNodeStatus)
+	}
+	v, loaded := nodeStatusKeys.LoadOrStore(info.Key, info)
+	if loaded && v.(NodeStatusKeyInfo).Type != info.Type {
+		return fmt.Errorf("key=%v is already registered as %v (%w)", info.Key, v.(NodeStatusKeyInfo).Type, ErrNodeStatus)
+	}
+	if loaded {
+		nodeStatusKeys.Store(info.Key, info)
+	}
+	return nil
 }
 
-func NodeStatusGetLogicalProcessors(s *NodeStatus) (int, error) {
-	v, ok := s.Get(NodeStatusLogicalProcessors)
+func LookupNodeStatusKey(k NodeStatusKey) (NodeStatusKeyInfo, bool) {
+	v, ok := nodeStatusKeys.Load(k)
 	if !ok {
-		return 0, fmt.Errorf("key=%+v not found", NodeStatusLogicalProcessors)
+		return NodeStatusKeyInfo{}, false
 	}
-	vv, err := strconv.Atoi(v)
-	if err != nil {
-		return 0, err
+	return v.(NodeStatusKeyInfo), true
+}
+
+// RegisteredNodeStatusKeys returns the registered keys sorted by Key.
+func RegisteredNodeStatusKeys() []NodeStatusKeyInfo {
+	var infos []NodeStatusKeyInfo
+	nodeStatusKeys.Range(func(_, v any) bool {
+		infos = append(infos, v.(NodeStatusKeyInfo))
+		return true
+	})
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	return infos
+}
+
+// NodeStatusProducer is an optional interface of NodeMonitors that declares the keys they set.
+type NodeStatusProducer interface {
+	ProducedNodeStatusKeys() []NodeStatusKey
+}
+
+// NodeStatusConsumer is an optional interface of PowerConsumptionPredictors that declares the keys they read.
+type NodeStatusConsumer interface {
+	ConsumedNodeStatusKeys() []NodeStatusKey
+}
+
+// consumedNodeStatusKeys returns the sorted union of the keys consumed by the predictors that implement NodeStatusConsumer.
+func consumedNodeStatusKeys(ps ...PowerConsumptionPredictor) []NodeStatusKey {
+	var keys []NodeStatusKey
+	for _, p := range ps {
+		if c, ok := p.(NodeStatusConsumer); ok {
+			keys = append(keys, c.ConsumedNodeStatusKeys()...)
+		}
+	}
+	return uniqueNodeStatusKeys(keys)
+}
+
+func uniqueNodeStatusKeys(keys []NodeStatusKey) []NodeStatusKey {
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	var unique []NodeStatusKey
+	for i, k := range keys {
+		if i == 0 || k != keys[i-1] {
+			unique = append(unique, k)
+		}
+	}
+	return unique
+}
+
+const (
+	// NodeStatusCPUUsage is the CPU usage of the node as a fraction of its logical processors (e.g. 0.25 for 1 of 4 CPUs),
+	// the predictors add the requested CPU to it in the same unit.
+	NodeStatusCPUUsage NodeStatusKey = "cpuUsage"
+	// NodeStatusAmbientTemp is the ambient (inlet) temperature of the node in Celsius.
+	NodeStatusAmbientTemp NodeStatusKey = "ambientTemp"
+	// NodeStatusStaticPressureDiff is the static pressure difference between the front and back of the node.
+	NodeStatusStaticPressureDiff NodeStatusKey = "staticPressureDiff"
+	// NodeStatusLogicalProcessors is the number of logical processors of the node.
+	NodeStatusLogicalProcessors NodeStatusKey = "logicalProcessors"
+	// NodeStatusPowerConsumption is the power consumption of the node in watts.
+	NodeStatusPowerConsumption NodeStatusKey = "powerConsumption"
+	// NodeStatusAllocatableCPUMilli is the allocatable CPU of the node in milli cores.
+	NodeStatusAllocatableCPUMilli NodeStatusKey = "allocatableCPUMilli"
+	// NodeStatusRequestedCPUMilli is the sum of CPU requests of the pods running on the node in milli cores.
+	NodeStatusRequestedCPUMilli NodeStatusKey = "requestedCPUMilli"
+)
+
+func init() {
+	for _, info := range []NodeStatusKeyInfo{
+		{Key: NodeStatusCPUUsage, Type: NodeStatusValueTypeFloat, Description: "CPU usage", NonNegative: true},
+		{Key: NodeStatusAmbientTemp, Type: NodeStatusValueTypeFloat, Unit: "Cel", Description: "ambient temperature"},
+		{Key: NodeStatusStaticPressureDiff, Type: NodeStatusValueTypeFloat, Unit: "Pa", Description: "static pressure difference"},
+		{Key: NodeStatusLogicalProcessors, Type: NodeStatusValueTypeInt, Description: "number of logical processors", NonNegative: true},
+		{Key: NodeStatusPowerConsumption, Type: NodeStatusValueTypeFloat, Unit: "W", Description: "power consumption", NonNegative: true},
+		{Key: NodeStatusAllocatableCPUMilli, Type: NodeStatusValueTypeInt, Unit: "m", Description: "allocatable CPU", NonNegative: true},
+		{Key: NodeStatusRequestedCPUMilli, Type: NodeStatusValueTypeInt, Unit: "m", Description: "requested CPU", NonNegative: true},
+	} {
+		if err := RegisterNodeStatusKey(info); err != nil {
+			panic(err)
+		}
 	}
-	return vv, nil
 }
@@ -0,0 +1,156 @@
+package estimator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// NodeStatusHistoryDefaultSize is the default number of NodeStatus snapshots kept per Node.
+const NodeStatusHistoryDefaultSize = 10
+
+type NodeStatusAggregationMethod string
+
+const (
+	// NodeStatusAggregationLast uses the latest value as is.
+	NodeStatusAggregationLast NodeStatusAggregationMethod = "Last"
+	// NodeStatusAggregationEWMA uses the exponentially weighted moving average of the values.
+	NodeStatusAggregationEWMA NodeStatusAggregationMethod = "EWMA"
+	// NodeStatusAggregationMedian uses the median of the values, robust against outliers.
+	NodeStatusAggregationMedian NodeStatusAggregationMethod = "Median"
+)
+
+// NodeStatusAggregationDefaultAlpha is the default smoothing factor of NodeStatusAggregationEWMA.
+const NodeStatusAggregationDefaultAlpha = 0.5
+
+// NodeStatusAggregation smooths the values of a key over the NodeStatus history,
+// e.g. to suppress noisy sensor values like NodeStatusStaticPressureDiff.
+//
+// Only float values are aggregated, the latest values of the other types are used as is.
+// Values kept as last-known-good are counted once.
+type NodeStatusAggregation struct {
+	// Method defaults to NodeStatusAggregationLast.
+	Method NodeStatusAggregationMethod
+	// Window is the number of the latest values aggregated, all the values in the history if not positive.
+	Window int
+	// Alpha is the smoothing factor of NodeStatusAggregationEWMA in (0, 1], larger values follow changes faster.
+	Alpha float64
+}
+
+// NewNodeStatusAggregation validates the given method and parameters, alpha defaults to NodeStatusAggregationDefaultAlpha if 0.
+func NewNodeStatusAggregation(method NodeStatusAggregationMethod, window int, alpha float64) (NodeStatusAggregation, error) {
+	switch method {
+	case "":
+		method = NodeStatusAggregationLast
+	case NodeStatusAggregationLast, NodeStatusAggregationEWMA, NodeStatusAggregationMedian:
+	default:
+		return NodeStatusAggregation{}, fmt.Errorf("unknown aggregation method %q (%w)", method, ErrNodeStatus)
+	}
+	if window < 0 {
+		return NodeStatusAggregation{}, fmt.Errorf("invalid aggregation window %v (%w)", window, ErrNodeStatus)
+	}
+	if alpha == 0 {
+		alpha = NodeStatusAggregationDefaultAlpha
+	}
+	if !(alpha > 0 && alpha <= 1) {
+		return NodeStatusAggregation{}, fmt.Errorf("invalid aggregation alpha %v (%w)", alpha, ErrNodeStatus)
+	}
+	return NodeStatusAggregation{Method: method, Window: window, Alpha: alpha}, nil
+}
+
+// aggregate returns the aggregated value of values ordered from the oldest.
+func (a NodeStatusAggregation) aggregate(values []float64) float64 {
+	if a.Window > 0 && len(values) > a.Window {
+		values = values[len(values)-a.Window:]
+	}
+	switch a.Method {
+	case NodeStatusAggregationEWMA:
+		alpha := a.Alpha
+		if !(alpha > 0 && alpha <= 1) {
+			alpha = NodeStatusAggregationDefaultAlpha
+		}
+		v := values[0]
+		for _, x := range values[1:] {
+			v = alpha*x + (1-alpha)*v
+		}
+		return v
+	case NodeStatusAggregationMedian:
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		n := len(sorted)
+		if n%2 == 1 {
+			return sorted[n/2]
+		}
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	default:
+		return values[len(values)-1]
+	}
+}
+
+// nodeStatusRing is a bounded history of NodeStatus snapshots, the oldest ones are dropped when it is full.
+type nodeStatusRing struct {
+	buf  []*NodeStatus
+	next int
+	full bool
+}
+
+func newNodeStatusRing(size int) *nodeStatusRing {
+	if size <= 0 {
+		size = 1
+	}
+	return &nodeStatusRing{buf: make([]*NodeStatus, size)}
+}
+
+func (r *nodeStatusRing) add(s *NodeStatus) {
+	r.buf[r.next] = s
+	r.next = (r.next + 1) % len(r.buf)
+	r.full = r.full || r.next == 0
+}
+
+// latest returns the last added snapshot, nil if empty.
+func (r *nodeStatusRing) latest() *NodeStatus {
+	return r.buf[(r.next-1+len(r.buf))%len(r.buf)]
+}
+
+// snapshots returns the snapshots ordered from the oldest.
+func (r *nodeStatusRing) snapshots() []*NodeStatus {
+	if !r.full {
+		return append([]*NodeStatus(nil), r.buf[:r.next]...)
+	}
+	return append(append([]*NodeStatus(nil), r.buf[r.next:]...), r.buf[:r.next]...)
+}
+
+// aggregateNodeStatus returns a copy of the latest snapshot in history with the values of aggs aggregated.
+// The aggregated values have the timestamps and sources of the latest values, so they become stale with them.
+func aggregateNodeStatus(history []*NodeStatus, aggs map[NodeStatusKey]NodeStatusAggregation) *NodeStatus {
+	latest := history[len(history)-1]
+	s := NewNodeStatus()
+	s.timestamp = latest.timestamp
+	s.maxAges = latest.maxAges
+	s.defaultMaxAge = latest.defaultMaxAge
+	for k, e := range latest.Entries() {
+		if agg, ok := aggs[k]; ok && e.Value.Type == NodeStatusValueTypeFloat {
+			if v := agg.aggregate(nodeStatusFloatValues(history, k)); !math.IsNaN(v) && !math.IsInf(v, 0) {
+				e.Value = NodeStatusFloat(v)
+			}
+		}
+		s.setEntry(k, e)
+	}
+	return s
+}
+
+// nodeStatusFloatValues returns the float values of the key in history ordered from the oldest,
+// values kept as last-known-good (i.e. with the same timestamp as the previous one) are skipped.
+func nodeStatusFloatValues(history []*NodeStatus, k NodeStatusKey) []float64 {
+	var values []float64
+	var prev NodeStatusEntry
+	for _, s := range history {
+		e, ok := s.Entry(k)
+		if !ok || e.Value.Type != NodeStatusValueTypeFloat || (len(values) != 0 && e.Timestamp.Equal(prev.Timestamp)) {
+			continue
+		}
+		values = append(values, e.Value.Float())
+		prev = e
+	}
+	return values
+}
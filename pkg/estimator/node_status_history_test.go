@@ -0,0 +1,112 @@
+package estimator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewNodeStatusAggregation(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  NodeStatusAggregationMethod
+		window  int
+		alpha   float64
+		want    NodeStatusAggregation
+		wantErr bool
+	}{
+		{"default", "", 0, 0, NodeStatusAggregation{Method: NodeStatusAggregationLast, Alpha: NodeStatusAggregationDefaultAlpha}, false},
+		{"ewma", NodeStatusAggregationEWMA, 5, 0.2, NodeStatusAggregation{Method: NodeStatusAggregationEWMA, Window: 5, Alpha: 0.2}, false},
+		{"unknown_method", "Mean", 0, 0, NodeStatusAggregation{}, true},
+		{"negative_window", NodeStatusAggregationMedian, -1, 0, NodeStatusAggregation{}, true},
+		{"alpha_gt_1", NodeStatusAggregationEWMA, 0, 1.5, NodeStatusAggregation{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewNodeStatusAggregation(tt.method, tt.window, tt.alpha)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewNodeStatusAggregation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrNodeStatus) {
+				t.Errorf("NewNodeStatusAggregation() error = %v, want ErrNodeStatus", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewNodeStatusAggregation() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeStatusAggregation_aggregate(t *testing.T) {
+	values := []float64{10, 12, 100, 11, 13}
+	tests := []struct {
+		name string
+		agg  NodeStatusAggregation
+		want float64
+	}{
+		{"last", NodeStatusAggregation{Method: NodeStatusAggregationLast}, 13},
+		{"median", NodeStatusAggregation{Method: NodeStatusAggregationMedian}, 12},
+		{"median_window", NodeStatusAggregation{Method: NodeStatusAggregationMedian, Window: 4}, 12.5},
+		{"ewma", NodeStatusAggregation{Method: NodeStatusAggregationEWMA, Window: 2, Alpha: 0.5}, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.agg.aggregate(values); got != tt.want {
+				t.Errorf("NodeStatusAggregation.aggregate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nodeStatusRing(t *testing.T) {
+	r := newNodeStatusRing(3)
+	if got := r.latest(); got != nil {
+		t.Errorf("nodeStatusRing.latest() = %v, want nil", got)
+	}
+	var added []*NodeStatus
+	for i := 0; i < 5; i++ {
+		s := NewNodeStatus()
+		r.add(s)
+		added = append(added, s)
+		if got := r.latest(); got != s {
+			t.Errorf("nodeStatusRing.latest() = %p, want %p", got, s)
+		}
+	}
+	if got := r.snapshots(); !reflect.DeepEqual(got, added[2:]) {
+		t.Errorf("nodeStatusRing.snapshots() = %v, want %v", got, added[2:])
+	}
+}
+
+func Test_aggregateNodeStatus(t *testing.T) {
+	now := time.Now()
+	snapshot := func(ts time.Time, pressure float64) *NodeStatus {
+		s := NewNodeStatus()
+		s.setEntry(NodeStatusStaticPressureDiff, NodeStatusEntry{Value: NodeStatusFloat(pressure), Timestamp: ts, Source: "DifferentialPressureAPINodeMonitor"})
+		s.setEntry(NodeStatusCPUUsage, NodeStatusEntry{Value: NodeStatusFloat(pressure), Timestamp: ts})
+		return s
+	}
+	history := []*NodeStatus{
+		snapshot(now.Add(-3*time.Second), 10),
+		snapshot(now.Add(-2*time.Second), 100), // outlier
+		snapshot(now.Add(-1*time.Second), 12),
+		snapshot(now.Add(-1*time.Second), 12), // last-known-good is counted once
+	}
+	got := aggregateNodeStatus(history, map[NodeStatusKey]NodeStatusAggregation{
+		NodeStatusStaticPressureDiff: {Method: NodeStatusAggregationMedian},
+	})
+
+	e, ok := got.Entry(NodeStatusStaticPressureDiff)
+	want := NodeStatusEntry{Value: NodeStatusFloat(12), Timestamp: now.Add(-1 * time.Second), Source: "DifferentialPressureAPINodeMonitor"}
+	if !ok || e != want {
+		t.Errorf("NodeStatus.Entry() = %+v, want %+v", e, want)
+	}
+	// not aggregated
+	if v, err := got.GetFloat(NodeStatusCPUUsage); err != nil || v != 12 {
+		t.Errorf("NodeStatus.GetFloat() = %v, %v, want 12", v, err)
+	}
+	// the history is not modified
+	if v, _ := history[1].GetFloat(NodeStatusStaticPressureDiff); v != 100 {
+		t.Errorf("NodeStatus.GetFloat() = %v, want 100", v)
+	}
+}
@@ -1,73 +1,192 @@
 package estimator
 
 import (
+	"errors"
+	"math"
+	"reflect"
 	"testing"
+	"time"
 )
 
-func TestNodeStatusGetCPUUsage(t *testing.T) {
+func TestNodeStatusValue(t *testing.T) {
 	tests := []struct {
-		name string
-		want float64
+		name       string
+		v          NodeStatusValue
+		wantType   NodeStatusValueType
+		wantFloat  float64
+		wantString string
 	}{
-		{"10.0", 10.0},
+		{"float", NodeStatusFloat(10.5), NodeStatusValueTypeFloat, 10.5, "10.5"},
+		{"int", NodeStatusInt(7500), NodeStatusValueTypeInt, 7500, "7500"},
+		{"duration", NodeStatusDuration(1500 * time.Millisecond), NodeStatusValueTypeDuration, 1.5, "1.5s"},
+		{"bool_true", NodeStatusBool(true), NodeStatusValueTypeBool, 1, "true"},
+		{"bool_false", NodeStatusBool(false), NodeStatusValueTypeBool, 0, "false"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewNodeStatus()
-			NodeStatusSetCPUUsage(s, tt.want)
-			got, err := NodeStatusGetCPUUsage(s)
-			if err != nil {
-				t.Errorf("NodeStatusGetCPUUsage() error = %v", err)
-				return
-			}
-			if got != tt.want {
-				t.Errorf("NodeStatusGetCPUUsage() = %v, want %v", got, tt.want)
+			if tt.v.Type != tt.wantType || tt.v.Float() != tt.wantFloat || tt.v.String() != tt.wantString {
+				t.Errorf("NodeStatusValue = %v %v %v, want %v %v %v", tt.v.Type, tt.v.Float(), tt.v.String(), tt.wantType, tt.wantFloat, tt.wantString)
 			}
 		})
 	}
 }
 
-func TestNodeStatusGetAmbientTemp(t *testing.T) {
+func TestNodeStatus_typed(t *testing.T) {
+	const (
+		keyFloat    NodeStatusKey = "test.float"
+		keyInt      NodeStatusKey = "test.int"
+		keyDuration NodeStatusKey = "test.duration"
+		keyBool     NodeStatusKey = "test.bool"
+	)
+	s := NewNodeStatus()
+	for _, err := range []error{
+		s.SetFloat(keyFloat, 250.5),
+		s.SetInt(keyInt, 1250),
+		s.SetDuration(keyDuration, time.Minute),
+		s.SetBool(keyBool, true),
+	} {
+		if err != nil {
+			t.Fatalf("NodeStatus.Set() error = %v", err)
+		}
+	}
+
+	if got, err := s.GetFloat(keyFloat); err != nil || got != 250.5 {
+		t.Errorf("NodeStatus.GetFloat() = %v, %v, want 250.5", got, err)
+	}
+	if got, err := s.GetInt(keyInt); err != nil || got != 1250 {
+		t.Errorf("NodeStatus.GetInt() = %v, %v, want 1250", got, err)
+	}
+	if got, err := s.GetDuration(keyDuration); err != nil || got != time.Minute {
+		t.Errorf("NodeStatus.GetDuration() = %v, %v, want 1m", got, err)
+	}
+	if got, err := s.GetBool(keyBool); err != nil || !got {
+		t.Errorf("NodeStatus.GetBool() = %v, %v, want true", got, err)
+	}
+	// any type can be read as float
+	if got, err := s.GetFloat(keyInt); err != nil || got != 1250 {
+		t.Errorf("NodeStatus.GetFloat() = %v, %v, want 1250", got, err)
+	}
+
+	for name, err := range map[string]error{
+		"not_found": func() error { _, err := s.GetFloat("test.notFound"); return err }(),
+		"int_float": func() error { _, err := s.GetInt(keyFloat); return err }(),
+		"bool_int":  func() error { _, err := s.GetBool(keyInt); return err }(),
+	} {
+		if !errors.Is(err, ErrNodeStatus) {
+			t.Errorf("%s: error = %v, want ErrNodeStatus", name, err)
+		}
+	}
+
+	s.Delete(keyFloat)
+	got := map[NodeStatusKey]string{}
+	s.Range(func(k NodeStatusKey, v NodeStatusValue) bool {
+		got[k] = v.String()
+		return true
+	})
+	want := map[NodeStatusKey]string{keyInt: "1250", keyDuration: "1m0s", keyBool: "true"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NodeStatus.Range() = %v, want %v", got, want)
+	}
+}
+
+func TestNodeStatus_Set_validation(t *testing.T) {
 	tests := []struct {
-		name string
-		want float64
+		name    string
+		k       NodeStatusKey
+		v       NodeStatusValue
+		wantErr bool
 	}{
-		{"10.0", 10.0},
+		{"registered", NodeStatusCPUUsage, NodeStatusFloat(10), false},
+		{"wrong_type", NodeStatusLogicalProcessors, NodeStatusFloat(4), true},
+		{"negative", NodeStatusPowerConsumption, NodeStatusFloat(-1), true},
+		{"negative_allowed", NodeStatusAmbientTemp, NodeStatusFloat(-5), false},
+		{"nan", NodeStatusCPUUsage, NodeStatusFloat(math.NaN()), true},
+		{"inf_unregistered", "test.unregistered", NodeStatusFloat(math.Inf(1)), true},
+		{"unregistered", "test.unregistered", NodeStatusBool(true), false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := NewNodeStatus()
-			NodeStatusSetAmbientTemp(s, tt.want)
-			got, err := NodeStatusGetAmbientTemp(s)
-			if err != nil {
-				t.Errorf("NodeStatusGetAmbientTemp() error = %v", err)
-				return
+			err := s.Set(tt.k, tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NodeStatus.Set() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if got != tt.want {
-				t.Errorf("NodeStatusGetAmbientTemp() = %v, want %v", got, tt.want)
+			if err != nil && !errors.Is(err, ErrNodeStatus) {
+				t.Errorf("NodeStatus.Set() error = %v, want ErrNodeStatus", err)
+			}
+			if _, ok := s.Get(tt.k); ok == tt.wantErr {
+				t.Errorf("NodeStatus.Get() ok = %v, invalid values must not be stored", ok)
 			}
 		})
 	}
 }
 
-func TestNodeStatusGetStaticPressureDiff(t *testing.T) {
+func TestRegisterNodeStatusKey(t *testing.T) {
+	info := NodeStatusKeyInfo{Key: "test.fanSpeed", Type: NodeStatusValueTypeFloat, Unit: "rpm", NonNegative: true}
+	if err := RegisterNodeStatusKey(info); err != nil {
+		t.Fatalf("RegisterNodeStatusKey() error = %v", err)
+	}
+	if got, ok := LookupNodeStatusKey(info.Key); !ok || got != info {
+		t.Errorf("LookupNodeStatusKey() = %+v, %v, want %+v", got, ok, info)
+	}
+	// the same type updates the info
+	info.Description = "fan speed"
+	if err := RegisterNodeStatusKey(info); err != nil {
+		t.Errorf("RegisterNodeStatusKey() error = %v", err)
+	}
+	if got, _ := LookupNodeStatusKey(info.Key); got.Description != info.Description {
+		t.Errorf("LookupNodeStatusKey() = %+v, want %+v", got, info)
+	}
+	if err := RegisterNodeStatusKey(NodeStatusKeyInfo{Key: info.Key, Type: NodeStatusValueTypeInt}); !errors.Is(err, ErrNodeStatus) {
+		t.Errorf("RegisterNodeStatusKey() error = %v, want ErrNodeStatus", err)
+	}
+	if err := RegisterNodeStatusKey(NodeStatusKeyInfo{}); !errors.Is(err, ErrNodeStatus) {
+		t.Errorf("RegisterNodeStatusKey() error = %v, want ErrNodeStatus", err)
+	}
+
+	found := false
+	for _, got := range RegisteredNodeStatusKeys() {
+		found = found || got.Key == NodeStatusCPUUsage
+	}
+	if !found {
+		t.Errorf("RegisteredNodeStatusKeys() does not include %v", NodeStatusCPUUsage)
+	}
+}
+
+func TestNodeStatus_Stale(t *testing.T) {
+	s := NewNodeStatus()
+	s.maxAges = map[NodeStatusKey]time.Duration{NodeStatusAmbientTemp: time.Hour, NodeStatusPowerConsumption: 0}
+	s.defaultMaxAge = time.Minute
+	old := time.Now().Add(-10 * time.Minute)
+	for _, k := range []NodeStatusKey{NodeStatusCPUUsage, NodeStatusAmbientTemp, NodeStatusPowerConsumption} {
+		s.setEntry(k, NodeStatusEntry{Value: NodeStatusFloat(10), Timestamp: old, Source: "FakeNodeMonitor"})
+	}
+	if err := s.SetFloat(NodeStatusStaticPressureDiff, 1); err != nil {
+		t.Fatalf("NodeStatus.SetFloat() error = %v", err)
+	}
+
 	tests := []struct {
-		name string
-		want float64
+		k         NodeStatusKey
+		wantStale bool
 	}{
-		{"10.0", 10.0},
+		{NodeStatusCPUUsage, true},          // older than the default max age
+		{NodeStatusAmbientTemp, false},      // the max age of the key is longer
+		{NodeStatusPowerConsumption, false}, // unlimited
+		{NodeStatusStaticPressureDiff, false},
+		{"test.notFound", false},
 	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := NewNodeStatus()
-			NodeStatusSetStaticPressureDiff(s, tt.want)
-			got, err := NodeStatusGetStaticPressureDiff(s)
-			if err != nil {
-				t.Errorf("NodeStatusGetStaticPressureDiff() error = %v", err)
-				return
+		t.Run(string(tt.k), func(t *testing.T) {
+			if got := s.Stale(tt.k); got != tt.wantStale {
+				t.Errorf("NodeStatus.Stale() = %v, want %v", got, tt.wantStale)
+			}
+			_, err := s.GetFloat(tt.k)
+			if got := errors.Is(err, ErrNodeStatusStale); got != tt.wantStale {
+				t.Errorf("NodeStatus.GetFloat() error = %v, wantStale %v", err, tt.wantStale)
 			}
-			if got != tt.want {
-				t.Errorf("NodeStatusGetStaticPressureDiff() = %v, want %v", got, tt.want)
+			// stale values are still returned by Get
+			if _, ok := s.Get(tt.k); !ok && tt.k != "test.notFound" {
+				t.Errorf("NodeStatus.Get() ok = %v, want true", ok)
 			}
 		})
 	}
@@ -2,6 +2,7 @@ package estimator
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -150,3 +151,134 @@ func TestNode_Predict(t *testing.T) {
 		})
 	}
 }
+
+func Test_missingNodeStatusKeys(t *testing.T) {
+	pc, err := NewPowerCurvePCPredictor([]PowerCurvePoint{{0, 50}, {1, 200}}, &AmbientTempCorrection{ReferenceTemp: 25, WattsPerDegree: 1})
+	if err != nil {
+		t.Fatalf("NewPowerCurvePCPredictor() error = %v", err)
+	}
+	fallback := &FallbackPCPredictor{Predictors: []FallbackPCPredictorItem{
+		{Predictor: &MLServerPCPredictor{V2TensorSpec: V2TensorSpec{Features: []NodeStatusKey{NodeStatusStaticPressureDiff}}}},
+		{Predictor: pc},
+	}}
+	tests := []struct {
+		name string
+		nms  []NodeMonitor
+		pcp  PowerConsumptionPredictor
+		want []NodeStatusKey
+	}{
+		{"all_produced", []NodeMonitor{&MetricsAPINodeMonitor{}, &IPMIExporterNodeMonitor{}}, pc, nil},
+		{"missing", []NodeMonitor{&MetricsAPINodeMonitor{}}, pc, []NodeStatusKey{NodeStatusAmbientTemp}},
+		{"fallback", []NodeMonitor{&MetricsAPINodeMonitor{}}, fallback, []NodeStatusKey{NodeStatusAmbientTemp, NodeStatusStaticPressureDiff}},
+		{"unknown_monitor", []NodeMonitor{&MetricsAPINodeMonitor{}, &FakeNodeMonitor{}}, pc, nil},
+		{"unknown_predictor", []NodeMonitor{&MetricsAPINodeMonitor{}}, testPCPredictorV1{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingNodeStatusKeys(tt.nms, tt.pcp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingNodeStatusKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNode_updateStatus(t *testing.T) {
+	fail := false
+	n := NewNode("n1", []NodeMonitor{
+		&FakeNodeMonitor{FetchFunc: func(_ context.Context, base *NodeStatus) error {
+			if fail {
+				return ErrNodeMonitor
+			}
+			return base.SetFloat(NodeStatusCPUUsage, 10)
+		}},
+	}, time.Second, nil)
+	n.NodeStatusMaxAges = map[NodeStatusKey]time.Duration{NodeStatusCPUUsage: time.Minute}
+
+	n.updateStatus()
+	e, ok := n.GetStatus().Entry(NodeStatusCPUUsage)
+	if !ok || e.Value.Float() != 10 || e.Source != "FakeNodeMonitor" {
+		t.Fatalf("NodeStatus.Entry() = %+v, %v, want 10 set by FakeNodeMonitor", e, ok)
+	}
+
+	// the last-known-good value is kept with its timestamp
+	fail = true
+	n.updateStatus()
+	status := n.GetStatus()
+	if got, ok := status.Entry(NodeStatusCPUUsage); !ok || got != e {
+		t.Fatalf("NodeStatus.Entry() = %+v, %v, want %+v", got, ok, e)
+	}
+	if got := status.MaxAge(NodeStatusCPUUsage); got != time.Minute {
+		t.Errorf("NodeStatus.MaxAge() = %v, want 1m", got)
+	}
+	if got := status.MaxAge(NodeStatusAmbientTemp); got != NodeStatusDefaultMaxAgeIntervals*time.Second {
+		t.Errorf("NodeStatus.MaxAge() = %v, want %v", got, NodeStatusDefaultMaxAgeIntervals*time.Second)
+	}
+
+	// the value is dropped once it is stale
+	n.NodeStatusMaxAges = map[NodeStatusKey]time.Duration{NodeStatusCPUUsage: time.Millisecond}
+	time.Sleep(2 * time.Millisecond)
+	n.updateStatus()
+	if got, ok := n.GetStatus().Entry(NodeStatusCPUUsage); ok {
+		t.Errorf("NodeStatus.Entry() = %+v, %v, want not found", got, ok)
+	}
+}
+
+func TestNode_updateStatus_aggregation(t *testing.T) {
+	values := []float64{10, 100, 12}
+	i := 0
+	n := NewNode("n1", []NodeMonitor{
+		&FakeNodeMonitor{FetchFunc: func(_ context.Context, base *NodeStatus) error {
+			defer func() { i++ }()
+			return base.SetFloat(NodeStatusStaticPressureDiff, values[i])
+		}},
+	}, time.Second, nil)
+	n.NodeStatusHistorySize = 2
+	n.NodeStatusAggregations = map[NodeStatusKey]NodeStatusAggregation{
+		NodeStatusStaticPressureDiff: {Method: NodeStatusAggregationMedian, Window: 3},
+	}
+
+	for range values {
+		// make the timestamps of the values differ
+		time.Sleep(time.Millisecond)
+		n.updateStatus()
+	}
+	if got, err := n.GetStatus().GetFloat(NodeStatusStaticPressureDiff); err != nil || got != 12 {
+		t.Errorf("NodeStatus.GetFloat() = %v, %v, want 12", got, err)
+	}
+	// the window is larger than NodeStatusHistorySize
+	history := n.GetStatusHistory()
+	if len(history) != 3 {
+		t.Fatalf("Node.GetStatusHistory() len = %v, want 3", len(history))
+	}
+	if got, _ := history[1].GetFloat(NodeStatusStaticPressureDiff); got != 100 {
+		t.Errorf("NodeStatus.GetFloat() = %v, want 100", got)
+	}
+}
+
+func TestNode_LastErrors(t *testing.T) {
+	n := NewNode("n1", []NodeMonitor{
+		&FakeNodeMonitor{FetchFunc: func(context.Context, *NodeStatus) error { return ErrNodeMonitor }},
+		&MetricsAPINodeMonitor{},
+	}, time.Second, &FakePCPredictor{PredictFunc: func(context.Context, int, *NodeStatus) (float64, error) { return 0.0, ErrPCPredictor }})
+
+	if got, want := n.MonitorNames(), []string{"FakeNodeMonitor", "MetricsAPINodeMonitor"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Node.MonitorNames() = %v, want %v", got, want)
+	}
+	if got := n.PredictorName(); got != "FakePCPredictor" {
+		t.Errorf("Node.PredictorName() = %v, want FakePCPredictor", got)
+	}
+	if got := n.LastErrors(); len(got) != 0 {
+		t.Errorf("Node.LastErrors() = %v, want empty", got)
+	}
+
+	_ = n.FetchStatus(context.Background(), nil)
+	_, _ = n.Predict(context.Background(), 500, nil)
+	var got []string
+	for _, e := range n.LastErrors() {
+		got = append(got, e.Source)
+	}
+	// MetricsAPINodeMonitor fails without a client
+	if want := []string{"FakeNodeMonitor", "FakePCPredictor", "MetricsAPINodeMonitor"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Node.LastErrors() sources = %v, want %v", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 )
@@ -60,16 +61,25 @@ func toDiff(vv [][]float64) ([][]float64, error) {
 	return xx, nil
 }
 
-type ComputeLeastCostPatternsFunc func(clusterNum, podNum int, wattMatrix [][]float64) (minWatt float64, minWattPatterns [][]int, err error)
+// ComputeLeastCostPatternsFunc computes minWatt and the patterns that achieve it for exactly podNum pods,
+// approximate is true if the result may not be optimal (e.g. computed on a sampled subproblem).
+type ComputeLeastCostPatternsFunc func(clusterNum, podNum int, wattMatrix [][]float64) (minWatt float64, minWattPatterns [][]int, approximate bool, err error)
 
 var ComputeLeastCostPatternsFn = findLeastCostPatternsExhaustiveWithSampling
 
-type ComputeLeastCostsFunc func(clusterNum, podNum int, wattMatrix [][]float64) (minWatts []float64, err error)
+// ComputeLeastCostsFunc computes minWatts[k-1] for all k in [1, podNum],
+// minWattPatterns[k-1] holds the patterns (the number of pods for each cluster) that achieve minWatts[k-1],
+// approximate is true if any of minWatts may not be optimal.
+type ComputeLeastCostsFunc func(clusterNum, podNum int, wattMatrix [][]float64) (minWatts []float64, minWattPatterns [][][]int, approximate bool, err error)
 
-var ComputeLeastCostsFn = findLeastCosts
+var ComputeLeastCostsFn = findLeastCostsDP
 
 const (
 	maxPowMN = 10_000_000
+
+	// maxLeastCostPatterns limits the number of patterns returned by ComputeLeastCostsFunc for each number of items,
+	// as ties may explode combinatorially (e.g. many identical boxes).
+	maxLeastCostPatterns = 100
 )
 
 // enumerateNdigitMbaseNumbers enumerates all N-digit M-base numbers that passed the given filter.
@@ -91,10 +101,10 @@ func enumerateNdigitMbaseNumbers(n, m int, filter func([]int) bool, expectedRetu
 	// 10^6: 50ms
 	// 10^7: 500ms
 	// 10^8: 5000ms
-	total := int(math.Pow(float64(m), float64(n)))
-	if total > maxPowMN {
-		return nil, fmt.Errorf("combinatorial explosion m=%d n=%d m^n=%d max=%d", m, n, total, maxPowMN)
+	if logPow(m, n) > math.Log(maxPowMN) {
+		return nil, fmt.Errorf("combinatorial explosion m=%d n=%d m^n=%.0f max=%d", m, n, math.Pow(float64(m), float64(n)), maxPowMN)
 	}
+	total := int(math.Pow(float64(m), float64(n)))
 
 	// it takes 500ms to grow an array from len=0 to len=10_000_000
 	pp := make([][]int, 0, expectedReturnLength)
@@ -125,7 +135,20 @@ const (
 	minN = 6
 )
 
-func findLeastCostPatternsExhaustiveWithSampling(box, itemsPerBox int, costs [][]float64) (minCost float64, minCostPatterns [][]int, err error) {
+// logPow returns log(m^n), which does not overflow unlike m^n.
+func logPow(m, n int) float64 {
+	return float64(n) * math.Log(float64(m))
+}
+
+// findLeastCostPatternsExhaustiveWithSampling is findLeastCostPatternsExhaustive for large inputs.
+//
+// If (itemsPerBox+1)^box exceeds maxPowMN, it samples a subproblem that fits:
+//   - rows: keeps the boxes with the least cost of the first item (i.e. the most promising boxes)
+//   - cols: caps the number of items per box
+//
+// then solves the subproblem exhaustively and pads the patterns back to box digits (dropped boxes get 0 items).
+// approximate is true if sampled, as the optimum may use the dropped boxes or more items per box.
+func findLeastCostPatternsExhaustiveWithSampling(box, itemsPerBox int, costs [][]float64) (minCost float64, minCostPatterns [][]int, approximate bool, err error) {
 	t := time.Now()
 	defer func() {
 		lg.Debug().Msgf("findLeastCostPatternsExhaustiveWithSampling total elapsed=%dms", time.Since(t).Milliseconds())
@@ -133,56 +156,74 @@ func findLeastCostPatternsExhaustiveWithSampling(box, itemsPerBox int, costs [][
 
 	row, col, err := is2DArray(costs)
 	if err != nil {
-		return 0.0, nil, nil
+		return 0.0, nil, false, nil
 	}
 	if row != box || col != itemsPerBox {
-		return 0.0, nil, errors.New("len(costs)!=box || len(costs[*])!=itemsPerBox")
+		return 0.0, nil, false, errors.New("len(costs)!=box || len(costs[*])!=itemsPerBox")
 	}
 
-	itemsPerBox++
-	total := int(math.Pow(float64(itemsPerBox), float64(box)))
-	for total > maxPowMN {
-		tmp1 := int(math.Pow(float64(itemsPerBox-1), float64(box)))
-		tmp2 := int(math.Pow(float64(itemsPerBox), float64(box-1)))
+	sampledBox := box
+	base := itemsPerBox + 1
+	// compare in log space as (itemsPerBox+1)^box overflows int for large inputs
+	for logPow(base, sampledBox) > math.Log(maxPowMN) {
+		tmp1 := logPow(base-1, sampledBox)
+		tmp2 := logPow(base, sampledBox-1)
 		if tmp1 > tmp2 {
-			if box <= minN {
-				itemsPerBox--
+			if sampledBox <= minN {
+				base--
 			} else {
-				box--
+				sampledBox--
 			}
 		} else {
-			if itemsPerBox <= minM {
-				box--
+			if base <= minM {
+				sampledBox--
 			} else {
-				itemsPerBox--
+				base--
 			}
 		}
-		total = int(math.Pow(float64(itemsPerBox), float64(box)))
-		lg.Debug().Msgf("sampling (%d,%d) -> (%d,%d) total=%d", row, col, box, itemsPerBox, total)
+		lg.Debug().Msgf("sampling (%d,%d) -> (%d,%d) total=%.0f", row, col, sampledBox, base-1, math.Pow(float64(base), float64(sampledBox)))
 	}
-	itemsPerBox--
+	maxItemsPerBox := base - 1
 
-	if row == box && col == itemsPerBox {
-		return findLeastCostPatternsExhaustive(box, itemsPerBox, costs)
+	if sampledBox == box && maxItemsPerBox == itemsPerBox {
+		minCost, minCostPatterns, err := findLeastCostPatternsExhaustive(box, itemsPerBox, costs)
+		return minCost, minCostPatterns, false, err
+	}
+	if sampledBox*maxItemsPerBox < itemsPerBox {
+		return 0.0, nil, true, fmt.Errorf("sampled (%d,%d) cannot hold %d items (%w)", sampledBox, maxItemsPerBox, itemsPerBox, ErrEstimator)
 	}
 
-	lg.Error().Msgf("large requests need sampling but not yet implemented")
-	return 0.0, nil, fmt.Errorf("large requests need sampling but not yet implemented")
-
-	// TODO: sampling
-	// var dropCols []int
-	// var dropRows []int
-	// sampledCosts := costs
+	// drop rows: keep the boxes with the least cost of the first item, in the original order
+	idx := make([]int, box)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return costs[idx[i]][0] < costs[idx[j]][0] })
+	keptBoxes := idx[:sampledBox]
+	sort.Ints(keptBoxes)
+
+	// drop cols: cap the number of items per box
+	sampledCosts := make([][]float64, sampledBox)
+	for i, b := range keptBoxes {
+		sampledCosts[i] = costs[b][:maxItemsPerBox]
+	}
+	lg.Info().Msgf("sampling (%d,%d) -> (%d,%d) keptBoxes=%v", row, col, sampledBox, maxItemsPerBox, keptBoxes)
 
-	// mc, mcp, err := findLeastCostPatternsExhaustive(box, itemsPerBox, sampledCosts)
-	// if err != nil {
-	// 	return 0.0, nil, err
-	// }
+	mc, mcp, err := leastCostPatternsExhaustive(itemsPerBox, sampledCosts)
+	if err != nil {
+		return 0.0, nil, true, err
+	}
 
-	// // TODO: padding
-	// paddedMCP := mcp
+	// padding: put 0 items into the dropped boxes
+	paddedMCP := make([][]int, len(mcp))
+	for i, p := range mcp {
+		paddedMCP[i] = make([]int, box)
+		for j, b := range keptBoxes {
+			paddedMCP[i][b] = p[j]
+		}
+	}
 
-	// return mc, paddedMCP, nil
+	return mc, paddedMCP, true, nil
 }
 
 func findLeastCostPatternsExhaustive(box, itemsPerBox int, costs [][]float64) (minCost float64, minCostPatterns [][]int, err error) {
@@ -199,12 +240,20 @@ func findLeastCostPatternsExhaustive(box, itemsPerBox int, costs [][]float64) (m
 		return 0.0, nil, errors.New("len(costs)!=box || len(costs[*])!=itemsPerBox")
 	}
 
+	return leastCostPatternsExhaustive(itemsPerBox, costs)
+}
+
+// leastCostPatternsExhaustive puts exactly items items into len(costs) boxes,
+// each box holds at most len(costs[*]) items.
+func leastCostPatternsExhaustive(items int, costs [][]float64) (minCost float64, minCostPatterns [][]int, err error) {
+	box, itemsPerBox := len(costs), len(costs[0])
+
 	filterFn := func(v []int) bool {
 		var sum int
 		for _, i := range v {
 			sum += i
 		}
-		return sum == itemsPerBox
+		return sum == items
 	}
 
 	// n=14 m=3 m^n=4782969 len(vv)=105
@@ -243,23 +292,27 @@ func findLeastCostPatternsExhaustive(box, itemsPerBox int, costs [][]float64) (m
 	return minCost, minCostPatterns, nil
 }
 
-func findLeastCosts(box, itemsPerBox int, costs [][]float64) (minCosts []float64, err error) {
+// findLeastCosts computes minCosts with ComputeLeastCostPatternsFn for each number of items,
+// approximate is true if any of them is approximate.
+func findLeastCosts(box, itemsPerBox int, costs [][]float64) (minCosts []float64, minCostPatterns [][][]int, approximate bool, err error) {
 	t := time.Now()
 	defer func() {
-		lg.Info().Msgf("findLeastCosts elapsed=%dms minCosts=%v", time.Since(t).Milliseconds(), minCosts)
+		lg.Info().Msgf("findLeastCosts elapsed=%dms minCosts=%v approximate=%v", time.Since(t).Milliseconds(), minCosts, approximate)
 	}()
 
 	row, col, err := is2DArray(costs)
 	if err != nil {
-		return []float64{}, nil
+		return []float64{}, [][][]int{}, false, nil
 	}
 	if row != box || col != itemsPerBox {
-		return nil, errors.New("len(costs)!=box || len(costs[*])!=itemsPerBox")
+		return nil, nil, false, errors.New("len(costs)!=box || len(costs[*])!=itemsPerBox")
 	}
 
 	wg := sync.WaitGroup{}
 	errs := make([]error, itemsPerBox)
+	approximates := make([]bool, itemsPerBox)
 	minCosts = make([]float64, itemsPerBox)
+	minCostPatterns = make([][][]int, itemsPerBox)
 	for m := itemsPerBox; m >= 1; m-- { // let it be fast by starting with large arrays
 		m := m
 		wg.Add(1)
@@ -270,8 +323,13 @@ func findLeastCosts(box, itemsPerBox int, costs [][]float64) (minCosts []float64
 			for i := 0; i < box; i++ {
 				curCosts = append(curCosts, costs[i][:m])
 			}
-			minCost, _, err := ComputeLeastCostPatternsFn(box, m, curCosts)
+			minCost, patterns, approximate, err := ComputeLeastCostPatternsFn(box, m, curCosts)
+			if approximate {
+				lg.Debug().Msgf("findLeastCosts got an approximate result for items=%d", m)
+			}
+			approximates[m-1] = approximate
 			minCosts[m-1] = minCost
+			minCostPatterns[m-1] = limitLeastCostPatterns(minCost, patterns)
 			errs[m-1] = err
 		}()
 	}
@@ -279,9 +337,266 @@ func findLeastCosts(box, itemsPerBox int, costs [][]float64) (minCosts []float64
 
 	for _, err := range errs {
 		if err != nil {
-			return minCosts, fmt.Errorf("one or more errors found err=%v (%w)", err, ErrEstimator)
+			return minCosts, minCostPatterns, false, fmt.Errorf("one or more errors found err=%v (%w)", err, ErrEstimator)
+		}
+	}
+	for _, a := range approximates {
+		approximate = approximate || a
+	}
+
+	return minCosts, minCostPatterns, approximate, nil
+}
+
+// limitLeastCostPatterns returns at most maxLeastCostPatterns patterns,
+// or no patterns if minCost is +Inf as such patterns are not feasible.
+func limitLeastCostPatterns(minCost float64, patterns [][]int) [][]int {
+	if math.IsInf(minCost, 1) {
+		return [][]int{}
+	}
+	if len(patterns) > maxLeastCostPatterns {
+		patterns = patterns[:maxLeastCostPatterns]
+	}
+	return append([][]int{}, patterns...)
+}
+
+// findLeastCostsDP computes minCosts[k-1] (the least cost to put k items into boxes) for all k in [1, itemsPerBox]
+// by dynamic programming over (box, items placed), i.e. a min-plus knapsack.
+//
+// leastCosts[i][k] = min_{c=0..k} ( leastCosts[i+1][k-c] + costs[i][c-1] ) (costs[*][-1] is 0)
+//
+// minCostPatterns[k-1] holds up to maxLeastCostPatterns patterns that achieve minCosts[k-1] in lexicographic order,
+// i.e. the same patterns as findLeastCosts.
+//
+// The result is exact, approximate is always false.
+//
+// Computational complexity: box*itemsPerBox^2 (+ enumerating patterns)
+func findLeastCostsDP(box, itemsPerBox int, costs [][]float64) (minCosts []float64, minCostPatterns [][][]int, approximate bool, err error) {
+	t := time.Now()
+	defer func() {
+		lg.Info().Msgf("findLeastCostsDP elapsed=%dms minCosts=%v", time.Since(t).Milliseconds(), minCosts)
+	}()
+
+	row, col, err := is2DArray(costs)
+	if err != nil {
+		return []float64{}, [][][]int{}, false, nil
+	}
+	if row != box || col != itemsPerBox {
+		return nil, nil, false, errors.New("len(costs)!=box || len(costs[*])!=itemsPerBox")
+	}
+
+	// leastCosts[i][k] holds the least cost to put k items into boxes [i, box),
+	// computed from the last box so that patterns are enumerated from the first box
+	leastCosts := make([][]float64, box+1)
+	leastCosts[box] = make([]float64, itemsPerBox+1)
+	for k := 1; k <= itemsPerBox; k++ {
+		leastCosts[box][k] = math.Inf(1)
+	}
+	for i := box - 1; i >= 0; i-- {
+		leastCosts[i] = make([]float64, itemsPerBox+1)
+		for k := 0; k <= itemsPerBox; k++ {
+			leastCosts[i][k] = leastCosts[i+1][k] // c=0
+			for c := 1; c <= k; c++ {
+				if v := leastCosts[i+1][k-c] + costs[i][c-1]; v < leastCosts[i][k] {
+					leastCosts[i][k] = v
+				}
+			}
+		}
+	}
+
+	minCosts = make([]float64, itemsPerBox)
+	copy(minCosts, leastCosts[0][1:])
+	minCostPatterns = make([][][]int, itemsPerBox)
+	for k := 1; k <= itemsPerBox; k++ {
+		minCostPatterns[k-1] = findLeastCostPatternsDP(leastCosts, costs, box, k)
+	}
+	return minCosts, minCostPatterns, false, nil
+}
+
+// findLeastCostPatternsDP enumerates up to maxLeastCostPatterns patterns that achieve leastCosts[0][items]
+// by backtracking the table computed in findLeastCostsDP.
+// The first box is enumerated first with ascending counts, so the patterns are the lexicographically first ones.
+func findLeastCostPatternsDP(leastCosts, costs [][]float64, box, items int) [][]int {
+	patterns := [][]int{}
+	if math.IsInf(leastCosts[0][items], 1) {
+		return patterns
+	}
+
+	pattern := make([]int, box)
+	var backtrack func(i, k int)
+	backtrack = func(i, k int) {
+		if len(patterns) >= maxLeastCostPatterns {
+			return
+		}
+		if i == box {
+			if k == 0 {
+				patterns = append(patterns, append([]int{}, pattern...))
+			}
+			return
+		}
+		for c := 0; c <= k; c++ {
+			var cost float64
+			if c > 0 {
+				cost = costs[i][c-1]
+			}
+			// the same expression as in findLeastCostsDP so ties are compared exactly
+			if leastCosts[i+1][k-c]+cost == leastCosts[i][k] {
+				pattern[i] = c
+				backtrack(i+1, k-c)
+			}
+		}
+		pattern[i] = 0
+	}
+	backtrack(0, items)
+	return patterns
+}
+
+// groupStates encodes vectors x (0 <= x[g] <= counts[g]) into integers in [0, Len()) in mixed radix,
+// so x-y is encoded as Encode(x)-Encode(y) if y <= x for all groups.
+type groupStates struct {
+	counts  []int
+	strides []int
+	n       int
+}
+
+func newGroupStates(counts []int) groupStates {
+	strides := make([]int, len(counts))
+	n := 1
+	for g := len(counts) - 1; g >= 0; g-- {
+		strides[g] = n
+		n *= counts[g] + 1
+	}
+	return groupStates{counts: counts, strides: strides, n: n}
+}
+
+func (gs groupStates) Len() int { return gs.n }
+
+func (gs groupStates) Encode(x []int) int {
+	s := 0
+	for g := range x {
+		s += x[g] * gs.strides[g]
+	}
+	return s
+}
+
+func (gs groupStates) Decode(s int) []int {
+	x := make([]int, len(gs.counts))
+	for g := range x {
+		x[g] = s / gs.strides[g]
+		s %= gs.strides[g]
+	}
+	return x
+}
+
+// transitions returns the number of pairs (x, y) such that y <= x, i.e. prod_g((counts[g]+1)(counts[g]+2)/2).
+func (gs groupStates) transitions() int {
+	n := 1
+	for _, c := range gs.counts {
+		n *= (c + 1) * (c + 2) / 2
+		if n > maxPowMN {
+			return n
+		}
+	}
+	return n
+}
+
+// subStates calls f for all y <= x (including 0 and x itself) with y encoded.
+func (gs groupStates) subStates(x []int, f func(y int)) {
+	y := make([]int, len(x))
+	for {
+		f(gs.Encode(y))
+		// increment y like an odometer
+		g := len(y) - 1
+		for ; g >= 0; g-- {
+			if y[g] < x[g] {
+				y[g]++
+				break
+			}
+			y[g] = 0
+		}
+		if g < 0 {
+			return
 		}
 	}
+}
+
+// findLeastCostGroupsDP computes the least cost to put counts[g] items of each group g into boxes
+// by dynamic programming over (box, items placed of each group), i.e. a multi-dimensional min-plus knapsack.
+//
+// costs[i][s] is the cost to put the items of the state s (see groupStates) into the box i, costs[i][0] must be 0.
+//
+// minCostPatterns holds up to maxLeastCostPatterns patterns [box][group] that achieve minCost in lexicographic order,
+// the first box is enumerated first as in findLeastCostPatternsDP.
+//
+// Computational complexity: box*prod_g((counts[g]+1)(counts[g]+2)/2)
+func findLeastCostGroupsDP(box int, counts []int, costs [][]float64) (minCost float64, minCostPatterns [][][]int, err error) {
+	t := time.Now()
+	defer func() {
+		lg.Info().Msgf("findLeastCostGroupsDP elapsed=%dms minCost=%v", time.Since(t).Milliseconds(), minCost)
+	}()
 
-	return minCosts, nil
+	gs := newGroupStates(counts)
+	if len(costs) != box {
+		return 0.0, nil, errors.New("len(costs)!=box")
+	}
+	for _, row := range costs {
+		if len(row) != gs.Len() {
+			return 0.0, nil, errors.New("len(costs[*])!=prod(counts[*]+1)")
+		}
+	}
+	if total := box * gs.transitions(); total > maxPowMN {
+		return 0.0, nil, fmt.Errorf("combinatorial explosion box=%d counts=%v total>=%d max=%d", box, counts, total, maxPowMN)
+	}
+
+	// leastCosts[i][s] holds the least cost to put the items of the state s into boxes [i, box)
+	leastCosts := make([][]float64, box+1)
+	leastCosts[box] = make([]float64, gs.Len())
+	for s := 1; s < gs.Len(); s++ {
+		leastCosts[box][s] = math.Inf(1)
+	}
+	for i := box - 1; i >= 0; i-- {
+		leastCosts[i] = make([]float64, gs.Len())
+		for s := 0; s < gs.Len(); s++ {
+			leastCosts[i][s] = math.Inf(1)
+			gs.subStates(gs.Decode(s), func(y int) {
+				if v := leastCosts[i+1][s-y] + costs[i][y]; v < leastCosts[i][s] {
+					leastCosts[i][s] = v
+				}
+			})
+		}
+	}
+
+	last := gs.Len() - 1
+	minCost = leastCosts[0][last]
+	minCostPatterns = [][][]int{}
+	if math.IsInf(minCost, 1) {
+		return minCost, minCostPatterns, nil
+	}
+
+	pattern := make([]int, box) // encoded states
+	var backtrack func(i, s int)
+	backtrack = func(i, s int) {
+		if len(minCostPatterns) >= maxLeastCostPatterns {
+			return
+		}
+		if i == box {
+			if s == 0 {
+				p := make([][]int, box)
+				for b := range p {
+					p[b] = gs.Decode(pattern[b])
+				}
+				minCostPatterns = append(minCostPatterns, p)
+			}
+			return
+		}
+		// y is ascending so the patterns are enumerated in lexicographic order
+		gs.subStates(gs.Decode(s), func(y int) {
+			// the same expression as above so ties are compared exactly
+			if leastCosts[i+1][s-y]+costs[i][y] == leastCosts[i][s] {
+				pattern[i] = y
+				backtrack(i+1, s-y)
+			}
+		})
+	}
+	backtrack(0, last)
+	return minCost, minCostPatterns, nil
 }
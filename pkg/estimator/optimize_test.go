@@ -1,6 +1,8 @@
 package estimator
 
 import (
+	"math"
+	"math/rand"
 	"reflect"
 	"testing"
 )
@@ -19,96 +21,430 @@ var (
 	testCostsAns1 = []float64{10.0, 14.0, 14.0, 15.0, 22.0, 29.0}
 )
 
+type findLeastCostsArgs struct {
+	box         int
+	itemsPerbox int
+	costs       [][]float64
+}
+
+var testFindLeastCostsCases = []struct {
+	name         string
+	args         findLeastCostsArgs
+	wantMinCosts []float64
+	wantErr      bool
+}{
+	{"8,6", findLeastCostsArgs{
+		box:         8,
+		itemsPerbox: 6,
+		costs:       testCostsReq1,
+	}, testCostsAns1, false},
+	{"1,6", findLeastCostsArgs{
+		box:         1,
+		itemsPerbox: 6,
+		costs: [][]float64{
+			{11.0, 14.0, 14.0, 16.0, 30.0, 31.0},
+		},
+	}, []float64{11.0, 14.0, 14.0, 16.0, 30.0, 31.0}, false},
+	{"8,1", findLeastCostsArgs{
+		box:         8,
+		itemsPerbox: 1,
+		costs: [][]float64{
+			{11.0},
+			{11.0},
+			{10.0},
+			{72.0},
+			{11.0},
+			{14.0},
+			{16.0},
+			{29.0},
+		},
+	}, []float64{10.0}, false},
+	{"1,1", findLeastCostsArgs{
+		box:         1,
+		itemsPerbox: 1,
+		costs: [][]float64{
+			{11.0},
+		},
+	}, []float64{11.0}, false},
+	{"0,0", findLeastCostsArgs{
+		box:         0,
+		itemsPerbox: 0,
+		costs:       [][]float64{},
+	}, []float64{}, false},
+	{"8,0", findLeastCostsArgs{
+		box:         8,
+		itemsPerbox: 0,
+		costs:       [][]float64{{}, {}, {}, {}, {}, {}, {}, {}},
+	}, []float64{}, false},
+	{"0,6 (array[0][6] is invalid so return error)", findLeastCostsArgs{
+		box:         0,
+		itemsPerbox: 6,
+		costs:       [][]float64{},
+	}, nil, true},
+	{"wrongX", findLeastCostsArgs{
+		box:         -1,
+		itemsPerbox: 6,
+		costs:       testCostsReq1,
+	}, nil, true},
+	{"wrongY", findLeastCostsArgs{
+		box:         8,
+		itemsPerbox: -1,
+		costs:       testCostsReq1,
+	}, nil, true},
+	{"wrongXY", findLeastCostsArgs{
+		box:         -1,
+		itemsPerbox: -1,
+		costs:       [][]float64{},
+	}, nil, true},
+}
+
 func Test_findLeastCosts(t *testing.T) {
+	for _, tt := range testFindLeastCostsCases {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMinCosts, _, _, err := findLeastCosts(tt.args.box, tt.args.itemsPerbox, tt.args.costs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("findLeastCosts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotMinCosts, tt.wantMinCosts) {
+				t.Errorf("findLeastCosts() = %v, want %v", gotMinCosts, tt.wantMinCosts)
+			}
+		})
+	}
+}
+
+func Test_findLeastCostsDP(t *testing.T) {
+	for _, tt := range testFindLeastCostsCases {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMinCosts, _, _, err := findLeastCostsDP(tt.args.box, tt.args.itemsPerbox, tt.args.costs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("findLeastCostsDP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotMinCosts, tt.wantMinCosts) {
+				t.Errorf("findLeastCostsDP() = %v, want %v", gotMinCosts, tt.wantMinCosts)
+			}
+		})
+	}
+}
+
+func Test_findLeastCostsDP_patterns(t *testing.T) {
+	tests := []struct {
+		name         string
+		costs        [][]float64
+		wantPatterns [][][]int
+	}{
+		{"ties", [][]float64{{1, 2}, {1, 3}}, [][][]int{
+			{{0, 1}, {1, 0}},
+			{{1, 1}, {2, 0}},
+		}},
+		{"inf", [][]float64{{math.Inf(1), math.Inf(1)}, {1, 3}}, [][][]int{
+			{{0, 1}},
+			{{0, 2}},
+		}},
+		{"all_inf", [][]float64{{math.Inf(1), math.Inf(1)}, {math.Inf(1), math.Inf(1)}}, [][][]int{{}, {}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, gotPatterns, _, err := findLeastCostsDP(len(tt.costs), len(tt.costs[0]), tt.costs)
+			if err != nil {
+				t.Errorf("findLeastCostsDP() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(gotPatterns, tt.wantPatterns) {
+				t.Errorf("findLeastCostsDP() patterns = %v, want %v", gotPatterns, tt.wantPatterns)
+			}
+		})
+	}
+}
+
+// newTestCosts returns a box*itemsPerBox cost matrix with random integer values,
+// each row is non-decreasing and some rows are filled with +Inf (i.e. errors).
+func newTestCosts(rnd *rand.Rand, box, itemsPerBox int) [][]float64 {
+	costs := make([][]float64, box)
+	for i := range costs {
+		costs[i] = make([]float64, itemsPerBox)
+		isErr := rnd.Intn(5) == 0
+		var v float64
+		for j := range costs[i] {
+			v += float64(rnd.Intn(20))
+			costs[i][j] = v
+			if isErr {
+				costs[i][j] = math.Inf(1)
+			}
+		}
+	}
+	return costs
+}
+
+func Test_findLeastCostsDP_equivalence(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for box := 1; box <= 6; box++ {
+		for itemsPerBox := 1; itemsPerBox <= 6; itemsPerBox++ {
+			for n := 0; n < 5; n++ {
+				costs := newTestCosts(rnd, box, itemsPerBox)
+				want, wantPatterns, _, err := findLeastCosts(box, itemsPerBox, costs)
+				if err != nil {
+					t.Fatalf("findLeastCosts() error = %v", err)
+				}
+				got, gotPatterns, _, err := findLeastCostsDP(box, itemsPerBox, costs)
+				if err != nil {
+					t.Fatalf("findLeastCostsDP() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("box=%d itemsPerBox=%d costs=%v findLeastCostsDP() = %v, findLeastCosts() = %v", box, itemsPerBox, costs, got, want)
+				}
+				if !reflect.DeepEqual(gotPatterns, wantPatterns) {
+					t.Errorf("box=%d itemsPerBox=%d costs=%v findLeastCostsDP() patterns = %v, findLeastCosts() patterns = %v", box, itemsPerBox, costs, gotPatterns, wantPatterns)
+				}
+			}
+		}
+	}
+}
+
+func Test_findLeastCostsDP_maxPatterns(t *testing.T) {
+	// identical boxes, C(4+7, 7)=330 patterns for 4 items are capped
+	box, itemsPerBox := 8, 4
+	costs := make([][]float64, box)
+	for i := range costs {
+		costs[i] = []float64{1, 2, 3, 4}
+	}
+	_, wantPatterns, _, err := findLeastCosts(box, itemsPerBox, costs)
+	if err != nil {
+		t.Fatalf("findLeastCosts() error = %v", err)
+	}
+	_, gotPatterns, _, err := findLeastCostsDP(box, itemsPerBox, costs)
+	if err != nil {
+		t.Fatalf("findLeastCostsDP() error = %v", err)
+	}
+	if len(gotPatterns[3]) != maxLeastCostPatterns {
+		t.Errorf("findLeastCostsDP() len(patterns[3]) = %d, want %d", len(gotPatterns[3]), maxLeastCostPatterns)
+	}
+	// the lexicographically first patterns
+	if want := []int{0, 0, 0, 0, 0, 0, 0, 4}; !reflect.DeepEqual(gotPatterns[3][0], want) {
+		t.Errorf("findLeastCostsDP() patterns[3][0] = %v, want %v", gotPatterns[3][0], want)
+	}
+	if !reflect.DeepEqual(gotPatterns, wantPatterns) {
+		t.Errorf("findLeastCostsDP() patterns = %v, findLeastCosts() patterns = %v", gotPatterns, wantPatterns)
+	}
+}
+
+func Test_findLeastCostsDP_large(t *testing.T) {
+	// (20+1)^50 patterns, too large for findLeastCosts
+	box, itemsPerBox := 50, 20
+	costs := make([][]float64, box)
+	for i := range costs {
+		costs[i] = make([]float64, itemsPerBox)
+		for j := range costs[i] {
+			costs[i][j] = float64((i%5+1)*(j+1)) + 10
+		}
+	}
+	got, gotPatterns, _, err := findLeastCostsDP(box, itemsPerBox, costs)
+	if err != nil {
+		t.Fatalf("findLeastCostsDP() error = %v", err)
+	}
+	// the cheapest nodes (i%5==0) cost 11 for the first workload and +1 for each additional workload
+	want := make([]float64, itemsPerBox)
+	for k := 1; k <= itemsPerBox; k++ {
+		want[k-1] = float64(k) + 10
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findLeastCostsDP() = %v, want %v", got, want)
+	}
+	// i.e. put all workloads into one of the 10 cheapest nodes
+	for k := 1; k <= itemsPerBox; k++ {
+		if len(gotPatterns[k-1]) != 10 {
+			t.Errorf("findLeastCostsDP() patterns[%d] = %v, want 10 patterns", k-1, gotPatterns[k-1])
+		}
+	}
+}
+
+func Test_findLeastCostPatternsExhaustiveWithSampling(t *testing.T) {
+	// small: same as findLeastCostPatternsExhaustive
+	costs := [][]float64{{1, 2}, {1, 3}}
+	gotMinCost, gotPatterns, gotApprox, err := findLeastCostPatternsExhaustiveWithSampling(2, 2, costs)
+	if err != nil || gotApprox || gotMinCost != 2 || !reflect.DeepEqual(gotPatterns, [][]int{{1, 1}, {2, 0}}) {
+		t.Errorf("findLeastCostPatternsExhaustiveWithSampling() = %v, %v, %v, %v", gotMinCost, gotPatterns, gotApprox, err)
+	}
+
+	// large: (5+1)^20 patterns, sampled to the cheapest boxes (i%4==0, +Inf for i%4==3)
+	box, itemsPerBox := 20, 5
+	costs = make([][]float64, box)
+	for i := range costs {
+		costs[i] = make([]float64, itemsPerBox)
+		for j := range costs[i] {
+			costs[i][j] = float64((i%4+1)*(j+1)) + 10
+			if i%4 == 3 {
+				costs[i][j] = math.Inf(1)
+			}
+		}
+	}
+	gotMinCost, gotPatterns, gotApprox, err = findLeastCostPatternsExhaustiveWithSampling(box, itemsPerBox, costs)
+	if err != nil {
+		t.Fatalf("findLeastCostPatternsExhaustiveWithSampling() error = %v", err)
+	}
+	if !gotApprox {
+		t.Errorf("findLeastCostPatternsExhaustiveWithSampling() approximate = false, want true")
+	}
+	// exact for these costs: all items into one of the cheapest boxes
+	wantMinCost, _, _, err := findLeastCostsDP(box, itemsPerBox, costs)
+	if err != nil {
+		t.Fatalf("findLeastCostsDP() error = %v", err)
+	}
+	if gotMinCost != wantMinCost[itemsPerBox-1] {
+		t.Errorf("findLeastCostPatternsExhaustiveWithSampling() minCost = %v, want %v", gotMinCost, wantMinCost[itemsPerBox-1])
+	}
+	if len(gotPatterns) == 0 {
+		t.Errorf("findLeastCostPatternsExhaustiveWithSampling() patterns = %v, want non-empty", gotPatterns)
+	}
+	for _, p := range gotPatterns {
+		var sum float64
+		for b, n := range p {
+			if n > 0 {
+				sum += costs[b][n-1]
+			}
+		}
+		if len(p) != box || sum != gotMinCost {
+			t.Errorf("findLeastCostPatternsExhaustiveWithSampling() pattern = %v (cost=%v), want len=%d cost=%v", p, sum, box, gotMinCost)
+		}
+	}
+
+	// findLeastCosts reports the sampled results
+	if _, _, gotApprox, err := findLeastCosts(box, itemsPerBox, costs); err != nil || !gotApprox {
+		t.Errorf("findLeastCosts() approximate = %v, %v, want true", gotApprox, err)
+	}
+}
+
+func Test_findLeastCostPatternsExhaustiveWithSampling_overflow(t *testing.T) {
+	// (itemsPerBox+1)^box overflows int
+	for _, tt := range []struct{ box, itemsPerBox int }{{50, 20}, {30, 10}} {
+		costs := make([][]float64, tt.box)
+		for i := range costs {
+			costs[i] = make([]float64, tt.itemsPerBox)
+			for j := range costs[i] {
+				costs[i][j] = float64((i%7+1)*(j+1)) + 10
+			}
+		}
+		gotMinCost, gotPatterns, gotApprox, err := findLeastCostPatternsExhaustiveWithSampling(tt.box, tt.itemsPerBox, costs)
+		if err != nil {
+			t.Fatalf("findLeastCostPatternsExhaustiveWithSampling(%d, %d) error = %v", tt.box, tt.itemsPerBox, err)
+		}
+		if math.IsInf(gotMinCost, 0) || math.IsNaN(gotMinCost) || len(gotPatterns) == 0 || !gotApprox {
+			t.Errorf("findLeastCostPatternsExhaustiveWithSampling(%d, %d) = %v, %v, %v, want finite cost, patterns and approximate", tt.box, tt.itemsPerBox, gotMinCost, gotPatterns, gotApprox)
+		}
+	}
+}
+
+// newTestGroupCosts converts costs[box][k-1] of a single group to costs[box][state] for findLeastCostGroupsDP.
+func newTestGroupCosts(costs [][]float64) [][]float64 {
+	groupCosts := make([][]float64, len(costs))
+	for i := range costs {
+		groupCosts[i] = append([]float64{0}, costs[i]...)
+	}
+	return groupCosts
+}
+
+func Test_findLeastCostGroupsDP(t *testing.T) {
+	inf := math.Inf(1)
 	type args struct {
-		box         int
-		itemsPerbox int
-		costs       [][]float64
+		box    int
+		counts []int
+		costs  [][]float64
 	}
 	tests := []struct {
 		name         string
 		args         args
-		wantMinCosts []float64
+		wantMinCost  float64
+		wantPatterns [][][]int
 		wantErr      bool
 	}{
-		{"8,6", args{
-			box:         8,
-			itemsPerbox: 6,
-			costs:       testCostsReq1,
-		}, testCostsAns1, false},
-		{"1,6", args{
-			box:         1,
-			itemsPerbox: 6,
-			costs: [][]float64{
-				{11.0, 14.0, 14.0, 16.0, 30.0, 31.0},
-			},
-		}, []float64{11.0, 14.0, 14.0, 16.0, 30.0, 31.0}, false},
-		{"8,1", args{
-			box:         8,
-			itemsPerbox: 1,
-			costs: [][]float64{
-				{11.0},
-				{11.0},
-				{10.0},
-				{72.0},
-				{11.0},
-				{14.0},
-				{16.0},
-				{29.0},
-			},
-		}, []float64{10.0}, false},
-		{"1,1", args{
-			box:         1,
-			itemsPerbox: 1,
-			costs: [][]float64{
-				{11.0},
-			},
-		}, []float64{11.0}, false},
-		{"0,0", args{
-			box:         0,
-			itemsPerbox: 0,
-			costs:       [][]float64{},
-		}, []float64{}, false},
-		{"8,0", args{
-			box:         8,
-			itemsPerbox: 0,
-			costs:       [][]float64{{}, {}, {}, {}, {}, {}, {}, {}},
-		}, []float64{}, false},
-		{"0,6 (array[0][6] is invalid so return error)", args{
-			box:         0,
-			itemsPerbox: 6,
-			costs:       [][]float64{},
-		}, nil, true},
-		{"wrongX", args{
-			box:         -1,
-			itemsPerbox: 6,
-			costs:       testCostsReq1,
-		}, nil, true},
-		{"wrongY", args{
-			box:         8,
-			itemsPerbox: -1,
-			costs:       testCostsReq1,
-		}, nil, true},
-		{"wrongXY", args{
-			box:         -1,
-			itemsPerbox: -1,
-			costs:       [][]float64{},
-		}, nil, true},
+		// states of counts=[1,2]: 0=[0,0] 1=[0,1] 2=[0,2] 3=[1,0] 4=[1,1] 5=[1,2]
+		{"2groups", args{2, []int{1, 2}, [][]float64{
+			{0, 1, 2, 8, 9, 10},
+			{0, 2, 4, 5, 7, 9},
+		}}, 7, [][][]int{
+			{{0, 2}, {1, 0}},
+		}, false},
+		{"2groups_ties", args{2, []int{1, 2}, [][]float64{
+			{0, 1, 2, 5, 6, 7},
+			{0, 1, 2, 5, 6, 7},
+		}}, 7, [][][]int{
+			{{0, 0}, {1, 2}},
+			{{0, 1}, {1, 1}},
+			{{0, 2}, {1, 0}},
+			{{1, 0}, {0, 2}},
+			{{1, 1}, {0, 1}},
+			{{1, 2}, {0, 0}},
+		}, false},
+		{"infeasible", args{2, []int{1, 2}, [][]float64{
+			{0, 1, inf, inf, inf, inf},
+			{0, 1, inf, inf, inf, inf},
+		}}, inf, [][][]int{}, false},
+		{"no_workloads", args{2, []int{}, [][]float64{{0}, {0}}}, 0, [][][]int{{{}, {}}}, false},
+		{"wrong_box", args{3, []int{1, 2}, [][]float64{{0, 1, 2, 3, 4, 5}}}, 0, nil, true},
+		{"wrong_states", args{1, []int{1, 2}, [][]float64{{0, 1, 2}}}, 0, nil, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotMinCosts, err := findLeastCosts(tt.args.box, tt.args.itemsPerbox, tt.args.costs)
+			gotMinCost, gotPatterns, err := findLeastCostGroupsDP(tt.args.box, tt.args.counts, tt.args.costs)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("calcMinCosts() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("findLeastCostGroupsDP() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(gotMinCosts, tt.wantMinCosts) {
-				t.Errorf("calcMinCosts() = %v, want %v", gotMinCosts, tt.wantMinCosts)
+			if tt.wantErr {
+				return
+			}
+			if gotMinCost != tt.wantMinCost {
+				t.Errorf("findLeastCostGroupsDP() minCost = %v, want %v", gotMinCost, tt.wantMinCost)
+			}
+			if !reflect.DeepEqual(gotPatterns, tt.wantPatterns) {
+				t.Errorf("findLeastCostGroupsDP() patterns = %v, want %v", gotPatterns, tt.wantPatterns)
 			}
 		})
 	}
 }
 
+func Test_findLeastCostGroupsDP_equivalence(t *testing.T) {
+	// a single group is the same as findLeastCostsDP
+	check := func(box, itemsPerBox int, costs [][]float64) {
+		want, wantPatterns, _, err := findLeastCostsDP(box, itemsPerBox, costs)
+		if err != nil {
+			t.Fatalf("findLeastCostsDP() error = %v", err)
+		}
+		got, gotPatterns, err := findLeastCostGroupsDP(box, []int{itemsPerBox}, newTestGroupCosts(costs))
+		if err != nil {
+			t.Fatalf("findLeastCostGroupsDP() error = %v", err)
+		}
+		if got != want[itemsPerBox-1] {
+			t.Errorf("box=%d itemsPerBox=%d costs=%v findLeastCostGroupsDP() = %v, findLeastCostsDP() = %v", box, itemsPerBox, costs, got, want[itemsPerBox-1])
+		}
+		flatPatterns := make([][]int, len(gotPatterns))
+		for p := range gotPatterns {
+			flatPatterns[p] = make([]int, box)
+			for b := range gotPatterns[p] {
+				flatPatterns[p][b] = gotPatterns[p][b][0]
+			}
+		}
+		if !reflect.DeepEqual(flatPatterns, wantPatterns[itemsPerBox-1]) {
+			t.Errorf("box=%d itemsPerBox=%d costs=%v findLeastCostGroupsDP() patterns = %v, findLeastCostsDP() patterns = %v", box, itemsPerBox, costs, flatPatterns, wantPatterns[itemsPerBox-1])
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for box := 1; box <= 6; box++ {
+		for itemsPerBox := 1; itemsPerBox <= 6; itemsPerBox++ {
+			check(box, itemsPerBox, newTestCosts(rnd, box, itemsPerBox))
+		}
+	}
+
+	// identical boxes, the patterns are capped
+	costs := make([][]float64, 8)
+	for i := range costs {
+		costs[i] = []float64{1, 2, 3, 4}
+	}
+	check(8, 4, costs)
+}
+
 var (
 	testCostsReq1BeforeToDiff = [][]float64{
 		{100.0, 111.0, 114.0, 114.0, 116.0, 130.0, 131.0},
@@ -3,17 +3,192 @@ package estimator
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
 type PowerConsumptionPredictor interface {
 	Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error)
 }
 
+// ResourceRequest is the amount of resources requested by the workloads placed on a node.
+type ResourceRequest struct {
+	CPUMilli    int
+	MemoryBytes int64
+	// ExtendedResources holds the requests of extended resources e.g. {"nvidia.com/gpu": 1}.
+	ExtendedResources map[string]int64
+}
+
+const (
+	// FeatureRequestMemoryBytes is a feature (V2TensorSpec.Features, PolynomialTerm.Powers) filled with
+	// ResourceRequest.MemoryBytes instead of a NodeStatus value.
+	FeatureRequestMemoryBytes NodeStatusKey = "requestMemoryBytes"
+	// FeatureRequestExtendedResourcePrefix followed by a resource name (e.g. "requestExtendedResources/nvidia.com/gpu")
+	// is a feature filled with ResourceRequest.ExtendedResources[name], 0 if not requested.
+	FeatureRequestExtendedResourcePrefix = "requestExtendedResources/"
+)
+
+// isRequestFeature returns true if k is filled with the ResourceRequest instead of a NodeStatus value.
+func isRequestFeature(k NodeStatusKey) bool {
+	return k == FeatureRequestMemoryBytes || strings.HasPrefix(string(k), FeatureRequestExtendedResourcePrefix)
+}
+
+// Feature returns the value of the request feature k, 0 if k is not a request feature.
+func (r ResourceRequest) Feature(k NodeStatusKey) float64 {
+	if k == FeatureRequestMemoryBytes {
+		return float64(r.MemoryBytes)
+	}
+	if strings.HasPrefix(string(k), FeatureRequestExtendedResourcePrefix) {
+		return float64(r.ExtendedResources[strings.TrimPrefix(string(k), FeatureRequestExtendedResourcePrefix)])
+	}
+	return 0
+}
+
+// Mul returns the resources requested by n workloads.
+func (r ResourceRequest) Mul(n int) ResourceRequest {
+	ret := ResourceRequest{
+		CPUMilli:    r.CPUMilli * n,
+		MemoryBytes: r.MemoryBytes * int64(n),
+	}
+	if r.ExtendedResources != nil {
+		ret.ExtendedResources = make(map[string]int64, len(r.ExtendedResources))
+		for k, v := range r.ExtendedResources {
+			ret.ExtendedResources[k] = v * int64(n)
+		}
+	}
+	return ret
+}
+
+// Add returns the sum of the resources.
+func (r ResourceRequest) Add(o ResourceRequest) ResourceRequest {
+	ret := ResourceRequest{
+		CPUMilli:    r.CPUMilli + o.CPUMilli,
+		MemoryBytes: r.MemoryBytes + o.MemoryBytes,
+	}
+	if r.ExtendedResources != nil || o.ExtendedResources != nil {
+		ret.ExtendedResources = map[string]int64{}
+		for k, v := range r.ExtendedResources {
+			ret.ExtendedResources[k] += v
+		}
+		for k, v := range o.ExtendedResources {
+			ret.ExtendedResources[k] += v
+		}
+	}
+	return ret
+}
+
+// PowerConsumptionPredictorV2 is a PowerConsumptionPredictor that takes resources other than CPU into account.
+type PowerConsumptionPredictorV2 interface {
+	PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error)
+}
+
+// PCPredictorV2Adapter adapts a PowerConsumptionPredictor to PowerConsumptionPredictorV2,
+// resources other than CPU are ignored, e.g. by PowerCurvePCPredictor.
+// Predictors using V2TensorSpec take them into account with the request features
+// (FeatureRequestMemoryBytes and FeatureRequestExtendedResourcePrefix).
+type PCPredictorV2Adapter struct {
+	PowerConsumptionPredictor
+}
+
+var _ PowerConsumptionPredictorV2 = (*PCPredictorV2Adapter)(nil)
+
+func (a *PCPredictorV2Adapter) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	if a.PowerConsumptionPredictor == nil {
+		return 0.0, ErrPCPredictorNotFound
+	}
+	return a.Predict(ctx, request.CPUMilli, status)
+}
+
+// ToPCPredictorV2 returns p itself if p implements PowerConsumptionPredictorV2, or p wrapped by PCPredictorV2Adapter.
+func ToPCPredictorV2(p PowerConsumptionPredictor) PowerConsumptionPredictorV2 {
+	if v2, ok := p.(PowerConsumptionPredictorV2); ok {
+		return v2
+	}
+	return &PCPredictorV2Adapter{PowerConsumptionPredictor: p}
+}
+
+// BatchPowerConsumptionPredictor is an optional interface of predictors that predict multiple requests at once,
+// watts[i] is the prediction for requests[i].
+type BatchPowerConsumptionPredictor interface {
+	PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error)
+}
+
+// PredictBatch uses p.PredictBatch if p implements BatchPowerConsumptionPredictor,
+// otherwise it calls PredictResources for each request.
+func PredictBatch(ctx context.Context, p PowerConsumptionPredictor, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	if bp, ok := p.(BatchPowerConsumptionPredictor); ok {
+		watts, err := bp.PredictBatch(ctx, requests, status)
+		if err != nil {
+			return nil, err
+		}
+		if len(watts) != len(requests) {
+			return nil, fmt.Errorf("PredictBatch returned %d values for %d requests (%w)", len(watts), len(requests), ErrPCPredictor)
+		}
+		return watts, nil
+	}
+	v2 := ToPCPredictorV2(p)
+	watts = make([]float64, len(requests))
+	for i, r := range requests {
+		watts[i], err = v2.PredictResources(ctx, r, status)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return watts, nil
+}
+
+// BatchPrediction holds the predictions returned by PredictBatchDetails.
+type BatchPrediction struct {
+	// Watts[i] is the prediction for requests[i].
+	Watts []float64
+	// Lower and Upper hold the bounds of the predictions (Lower[i] <= Watts[i] <= Upper[i]),
+	// both are nil if the predictor does not know its error bounds.
+	Lower []float64
+	Upper []float64
+	// Source is the name of the predictor that produced the predictions (e.g. by FallbackPCPredictor), empty if unknown.
+	Source string
+}
+
+// HasInterval returns true if the prediction has the bounds.
+func (p *BatchPrediction) HasInterval() bool { return p.Lower != nil && p.Upper != nil }
+
+// DetailedPCPredictor is an optional interface of predictors that report the prediction intervals
+// or the names of the predictors that produced the predictions.
+type DetailedPCPredictor interface {
+	PredictBatchDetails(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error)
+}
+
+// PredictBatchDetails uses p.PredictBatchDetails if p implements DetailedPCPredictor,
+// otherwise it calls PredictBatch and returns the predictions without the bounds and the source.
+func PredictBatchDetails(ctx context.Context, p PowerConsumptionPredictor, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error) {
+	dp, ok := p.(DetailedPCPredictor)
+	if !ok {
+		watts, err := PredictBatch(ctx, p, requests, status)
+		if err != nil {
+			return nil, err
+		}
+		return &BatchPrediction{Watts: watts}, nil
+	}
+	pred, err := dp.PredictBatchDetails(ctx, requests, status)
+	if err != nil {
+		return nil, err
+	}
+	if len(pred.Watts) != len(requests) {
+		return nil, fmt.Errorf("PredictBatchDetails returned %d values for %d requests (%w)", len(pred.Watts), len(requests), ErrPCPredictor)
+	}
+	if pred.HasInterval() && (len(pred.Lower) != len(requests) || len(pred.Upper) != len(requests)) {
+		return nil, fmt.Errorf("PredictBatchDetails returned %d lower and %d upper bounds for %d requests (%w)", len(pred.Lower), len(pred.Upper), len(requests), ErrPCPredictor)
+	}
+	return pred, nil
+}
+
 type FakePCPredictor struct {
 	PredictFunc func(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error)
+	// PredictResourcesFunc is used by PredictResources if set, otherwise PredictFunc is used with the CPU request.
+	PredictResourcesFunc func(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error)
 }
 
 var _ PowerConsumptionPredictor = (*FakePCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*FakePCPredictor)(nil)
 
 func (p *FakePCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
 	if p.PredictFunc == nil {
@@ -22,13 +197,20 @@ func (p *FakePCPredictor) Predict(ctx context.Context, requestCPUMilli int, stat
 	return p.PredictFunc(ctx, requestCPUMilli, status)
 }
 
+func (p *FakePCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	if p.PredictResourcesFunc == nil {
+		return p.Predict(ctx, request.CPUMilli, status)
+	}
+	return p.PredictResourcesFunc(ctx, request, status)
+}
+
 // PredictPCFnDummy returns ( mCPU/100 + CPUUsage + AmbientTemp )
 func PredictPCFnDummy(_ context.Context, mcpu int, status *NodeStatus) (float64, error) {
-	at, err := NodeStatusGetAmbientTemp(status)
+	at, err := status.GetFloat(NodeStatusAmbientTemp)
 	if err != nil {
 		return 0.0, err
 	}
-	cu, err := NodeStatusGetCPUUsage(status)
+	cu, err := status.GetFloat(NodeStatusCPUUsage)
 	if err != nil {
 		return 0.0, err
 	}
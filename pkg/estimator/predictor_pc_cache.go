@@ -0,0 +1,321 @@
+package estimator
+
+import (
+	"container/list"
+	"context"
+	"hash/fnv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	PCPredictionCacheDefaultSize = 10000
+	PCPredictionCacheDefaultTTL  = 30 * time.Second
+)
+
+// PCPredictionCache is an LRU cache of predictions shared by CachedPCPredictors,
+// entries are keyed by (node, request, NodeStatus content hash) and expire after TTL.
+//
+// NodeStatus only changes every refresh interval, so repeated estimations within an interval are served from memory.
+type PCPredictionCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List // front is the most recently used
+	entries map[pcPredictionCacheKey]*list.Element
+
+	hits   uint64
+	misses uint64
+}
+
+type pcPredictionCacheKey struct {
+	node              string
+	cpuMilli          int
+	memoryBytes       int64
+	extendedResources string
+	status            uint64
+}
+
+type pcPredictionCacheEntry struct {
+	key  pcPredictionCacheKey
+	watt float64
+	// lower and upper are the bounds of watt if hasInterval is true
+	lower       float64
+	upper       float64
+	hasInterval bool
+	source      string
+	expires     time.Time
+}
+
+// PCPredictionCacheStats holds the counters of a PCPredictionCache.
+type PCPredictionCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Len is the number of entries including expired ones not yet evicted.
+	Len int
+}
+
+// NewPCPredictionCache returns a PCPredictionCache holding at most size entries for ttl,
+// the defaults are used if size or ttl is not positive.
+func NewPCPredictionCache(size int, ttl time.Duration) *PCPredictionCache {
+	if size <= 0 {
+		size = PCPredictionCacheDefaultSize
+	}
+	if ttl <= 0 {
+		ttl = PCPredictionCacheDefaultTTL
+	}
+	return &PCPredictionCache{
+		size:    size,
+		ttl:     ttl,
+		lru:     list.New(),
+		entries: map[pcPredictionCacheKey]*list.Element{},
+	}
+}
+
+// Reset drops all the entries and applies the given size and ttl in the same way as NewPCPredictionCache,
+// the counters are kept so that they cover the lifetime of the Estimator rather than a single reconciliation.
+func (c *PCPredictionCache) Reset(size int, ttl time.Duration) {
+	if size <= 0 {
+		size = PCPredictionCacheDefaultSize
+	}
+	if ttl <= 0 {
+		ttl = PCPredictionCacheDefaultTTL
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = size
+	c.ttl = ttl
+	c.lru.Init()
+	c.entries = map[pcPredictionCacheKey]*list.Element{}
+}
+
+// Stats returns the current counters.
+func (c *PCPredictionCache) Stats() PCPredictionCacheStats {
+	c.mu.Lock()
+	l := c.lru.Len()
+	c.mu.Unlock()
+	return PCPredictionCacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+		Len:    l,
+	}
+}
+
+func (c *PCPredictionCache) get(k pcPredictionCacheKey) (*pcPredictionCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[k]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	e := el.Value.(*pcPredictionCacheEntry)
+	if time.Now().After(e.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, k)
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	atomic.AddUint64(&c.hits, 1)
+	return e, true
+}
+
+// newPCPredictionCacheEntry returns the entry of the i-th prediction in pred.
+func newPCPredictionCacheEntry(k pcPredictionCacheKey, pred *BatchPrediction, i int) *pcPredictionCacheEntry {
+	e := &pcPredictionCacheEntry{key: k, watt: pred.Watts[i], source: pred.Source}
+	if pred.HasInterval() {
+		e.lower, e.upper, e.hasInterval = pred.Lower[i], pred.Upper[i], true
+	}
+	return e
+}
+
+func (c *PCPredictionCache) add(e *pcPredictionCacheEntry) {
+	k := e.key
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.expires = time.Now().Add(c.ttl)
+	if el, ok := c.entries[k]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[k] = c.lru.PushFront(e)
+	for c.lru.Len() > c.size {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*pcPredictionCacheEntry).key)
+	}
+}
+
+// CachedPCPredictor caches the predictions of Predictor in Cache, errors are not cached.
+type CachedPCPredictor struct {
+	// NodeName is a part of the cache keys as Cache is shared by the nodes.
+	NodeName  string
+	Predictor PowerConsumptionPredictor
+	Cache     *PCPredictionCache
+}
+
+var _ PowerConsumptionPredictor = (*CachedPCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*CachedPCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*CachedPCPredictor)(nil)
+var _ DetailedPCPredictor = (*CachedPCPredictor)(nil)
+var _ NodeStatusConsumer = (*CachedPCPredictor)(nil)
+var _ io.Closer = (*CachedPCPredictor)(nil)
+
+func (p *CachedPCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	return p.PredictResources(ctx, ResourceRequest{CPUMilli: requestCPUMilli}, status)
+}
+
+func (p *CachedPCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	pred, err := p.PredictBatchDetails(ctx, []ResourceRequest{request}, status)
+	if err != nil {
+		return math.MaxFloat64, err
+	}
+	return pred.Watts[0], nil
+}
+
+func (p *CachedPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	pred, err := p.PredictBatchDetails(ctx, requests, status)
+	if err != nil {
+		return nil, err
+	}
+	return pred.Watts, nil
+}
+
+// PredictBatchDetails predicts only the requests not in Cache with one call of Predictor.
+// The source is that of the call if any, otherwise that of the first cached prediction.
+func (p *CachedPCPredictor) PredictBatchDetails(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error) {
+	if p.Predictor == nil {
+		return nil, ErrPCPredictorNotFound
+	}
+	if p.Cache == nil {
+		return PredictBatchDetails(ctx, p.Predictor, requests, status)
+	}
+
+	statusHash := hashNodeStatus(status)
+	keys := make([]pcPredictionCacheKey, len(requests))
+	entries := make([]*pcPredictionCacheEntry, len(requests))
+	var missed []int
+	for i, r := range requests {
+		keys[i] = newPCPredictionCacheKey(p.NodeName, r, statusHash)
+		e, ok := p.Cache.get(keys[i])
+		if !ok {
+			missed = append(missed, i)
+			continue
+		}
+		entries[i] = e
+	}
+
+	var missedPred *BatchPrediction
+	if len(missed) != 0 {
+		missedRequests := make([]ResourceRequest, len(missed))
+		for j, i := range missed {
+			missedRequests[j] = requests[i]
+		}
+		var err error
+		missedPred, err = PredictBatchDetails(ctx, p.Predictor, missedRequests, status)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range missed {
+			entries[i] = newPCPredictionCacheEntry(keys[i], missedPred, j)
+			p.Cache.add(entries[i])
+		}
+	}
+
+	return newBatchPredictionFromCache(entries, missedPred), nil
+}
+
+// newBatchPredictionFromCache merges the entries, the predictions are used as the bounds of entries without them.
+func newBatchPredictionFromCache(entries []*pcPredictionCacheEntry, missedPred *BatchPrediction) *BatchPrediction {
+	pred := &BatchPrediction{Watts: make([]float64, len(entries))}
+	hasInterval := false
+	for i, e := range entries {
+		pred.Watts[i] = e.watt
+		if pred.Source == "" {
+			pred.Source = e.source
+		}
+		hasInterval = hasInterval || e.hasInterval
+	}
+	if missedPred != nil {
+		pred.Source = missedPred.Source
+	}
+	if hasInterval {
+		pred.Lower = make([]float64, len(entries))
+		pred.Upper = make([]float64, len(entries))
+		for i, e := range entries {
+			pred.Lower[i], pred.Upper[i] = e.watt, e.watt
+			if e.hasInterval {
+				pred.Lower[i], pred.Upper[i] = e.lower, e.upper
+			}
+		}
+	}
+	return pred
+}
+
+// ConsumedNodeStatusKeys returns the keys consumed by Predictor.
+func (p *CachedPCPredictor) ConsumedNodeStatusKeys() []NodeStatusKey {
+	return consumedNodeStatusKeys(p.Predictor)
+}
+
+// Close closes Predictor if it implements io.Closer.
+func (p *CachedPCPredictor) Close() error {
+	if c, ok := p.Predictor.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func newPCPredictionCacheKey(node string, r ResourceRequest, statusHash uint64) pcPredictionCacheKey {
+	k := pcPredictionCacheKey{
+		node:        node,
+		cpuMilli:    r.CPUMilli,
+		memoryBytes: r.MemoryBytes,
+		status:      statusHash,
+	}
+	if len(r.ExtendedResources) != 0 {
+		names := make([]string, 0, len(r.ExtendedResources))
+		for name := range r.ExtendedResources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var b []byte
+		for _, name := range names {
+			b = append(b, name...)
+			b = append(b, '=')
+			b = strconv.AppendInt(b, r.ExtendedResources[name], 10)
+			b = append(b, ',')
+		}
+		k.extendedResources = string(b)
+	}
+	return k
+}
+
+// hashNodeStatus returns a hash of the keys, values and staleness in the NodeStatus, the timestamps are ignored.
+func hashNodeStatus(s *NodeStatus) uint64 {
+	if s == nil {
+		return 0
+	}
+	var kvs []string
+	for k, e := range s.Entries() {
+		kv := string(k) + "=" + e.Value.Type.String() + ":" + e.Value.String()
+		if s.stale(k, e) {
+			kv += ":stale"
+		}
+		kvs = append(kvs, kv)
+	}
+	sort.Strings(kvs)
+	h := fnv.New64a()
+	for _, kv := range kvs {
+		h.Write([]byte(kv))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,150 @@
+package estimator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCachedPCPredictor_PredictBatch(t *testing.T) {
+	ctx := context.Background()
+	inner := &testBatchPCPredictor{}
+	cache := NewPCPredictionCache(0, time.Minute)
+	p := &CachedPCPredictor{NodeName: "n0", Predictor: inner, Cache: cache}
+	status := newNodeStatus(10, 20)
+
+	check := func(requests []ResourceRequest, status *NodeStatus, want []float64, wantCalls int, wantStats PCPredictionCacheStats) {
+		t.Helper()
+		got, err := p.PredictBatch(ctx, requests, status)
+		if err != nil {
+			t.Fatalf("CachedPCPredictor.PredictBatch() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CachedPCPredictor.PredictBatch() = %v, want %v", got, want)
+		}
+		if inner.calls != wantCalls {
+			t.Errorf("Predictor.PredictBatch() called %d times, want %d", inner.calls, wantCalls)
+		}
+		if stats := cache.Stats(); stats != wantStats {
+			t.Errorf("PCPredictionCache.Stats() = %+v, want %+v", stats, wantStats)
+		}
+	}
+
+	check([]ResourceRequest{{CPUMilli: 500}, {CPUMilli: 1000}}, status, []float64{5, 10}, 1, PCPredictionCacheStats{Hits: 0, Misses: 2, Len: 2})
+	// served from the cache
+	check([]ResourceRequest{{CPUMilli: 500}, {CPUMilli: 1000}}, status, []float64{5, 10}, 1, PCPredictionCacheStats{Hits: 2, Misses: 2, Len: 2})
+	// only the missing request is predicted
+	check([]ResourceRequest{{CPUMilli: 500}, {CPUMilli: 1500}}, status, []float64{5, 15}, 2, PCPredictionCacheStats{Hits: 3, Misses: 3, Len: 3})
+	// the same NodeStatus values with a new timestamp hit the cache
+	check([]ResourceRequest{{CPUMilli: 500}}, newNodeStatus(10, 20), []float64{5}, 2, PCPredictionCacheStats{Hits: 4, Misses: 3, Len: 3})
+	// other NodeStatus values miss the cache
+	check([]ResourceRequest{{CPUMilli: 500}}, newNodeStatus(11, 20), []float64{5}, 3, PCPredictionCacheStats{Hits: 4, Misses: 4, Len: 4})
+	// other resources miss the cache
+	check([]ResourceRequest{{CPUMilli: 500, ExtendedResources: map[string]int64{"nvidia.com/gpu": 1}}}, status, []float64{5}, 4, PCPredictionCacheStats{Hits: 4, Misses: 5, Len: 5})
+
+	// other nodes sharing the cache miss it
+	p1 := &CachedPCPredictor{NodeName: "n1", Predictor: inner, Cache: cache}
+	if _, err := p1.PredictBatch(ctx, []ResourceRequest{{CPUMilli: 500}}, status); err != nil || inner.calls != 5 {
+		t.Errorf("CachedPCPredictor.PredictBatch() error = %v calls = %d, want 5", err, inner.calls)
+	}
+}
+
+func TestCachedPCPredictor_errors(t *testing.T) {
+	var calls int
+	inner := &FakePCPredictor{PredictFunc: func(context.Context, int, *NodeStatus) (float64, error) {
+		calls++
+		return 0.0, ErrPCPredictor
+	}}
+	p := &CachedPCPredictor{NodeName: "n0", Predictor: inner, Cache: NewPCPredictionCache(0, 0)}
+	for i := 0; i < 2; i++ {
+		if _, err := p.Predict(context.Background(), 500, NewNodeStatus()); err == nil {
+			t.Errorf("CachedPCPredictor.Predict() error = nil")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("errors must not be cached, Predictor.Predict() called %d times, want 2", calls)
+	}
+	if got := p.Cache.Stats().Len; got != 0 {
+		t.Errorf("PCPredictionCache.Stats().Len = %d, want 0", got)
+	}
+}
+
+func TestCachedPCPredictor_source(t *testing.T) {
+	inner := &FallbackPCPredictor{Predictors: []FallbackPCPredictorItem{{Name: "PowerCurve", Predictor: testPCPredictorV1{}}}}
+	p := &CachedPCPredictor{NodeName: "n0", Predictor: inner, Cache: NewPCPredictionCache(0, 0)}
+	for i := 0; i < 2; i++ {
+		pred, err := p.PredictBatchDetails(context.Background(), []ResourceRequest{{CPUMilli: 500}}, NewNodeStatus())
+		if err != nil || pred.Source != "PowerCurve" {
+			t.Errorf("CachedPCPredictor.PredictBatchDetails() = %+v, %v, want PowerCurve", pred, err)
+		}
+	}
+}
+
+func TestPCPredictionCache(t *testing.T) {
+	k := func(cpuMilli int) pcPredictionCacheKey {
+		return newPCPredictionCacheKey("n0", ResourceRequest{CPUMilli: cpuMilli}, 0)
+	}
+
+	// size
+	c := NewPCPredictionCache(2, time.Minute)
+	c.add(&pcPredictionCacheEntry{key: k(0), watt: 0})
+	c.add(&pcPredictionCacheEntry{key: k(1), watt: 1})
+	c.get(k(0)) // k(1) is the least recently used
+	c.add(&pcPredictionCacheEntry{key: k(2), watt: 2})
+	if _, ok := c.get(k(1)); ok {
+		t.Errorf("PCPredictionCache.get() evicted entry found")
+	}
+	for _, i := range []int{0, 2} {
+		if e, ok := c.get(k(i)); !ok || e.watt != float64(i) {
+			t.Errorf("PCPredictionCache.get(%d) = %+v, %v", i, e, ok)
+		}
+	}
+	if got := c.Stats().Len; got != 2 {
+		t.Errorf("PCPredictionCache.Stats().Len = %d, want 2", got)
+	}
+
+	// TTL
+	c = NewPCPredictionCache(2, time.Millisecond)
+	c.add(&pcPredictionCacheEntry{key: k(0), watt: 0})
+	time.Sleep(10 * time.Millisecond)
+	if _, ok := c.get(k(0)); ok {
+		t.Errorf("PCPredictionCache.get() expired entry found")
+	}
+	if got := c.Stats(); got.Len != 0 || got.Misses != 1 {
+		t.Errorf("PCPredictionCache.Stats() = %+v", got)
+	}
+
+	// Reset drops the entries but keeps the counters
+	c = NewPCPredictionCache(2, time.Minute)
+	c.add(&pcPredictionCacheEntry{key: k(0), watt: 0})
+	c.get(k(0))
+	c.Reset(1, time.Minute)
+	if _, ok := c.get(k(0)); ok {
+		t.Errorf("PCPredictionCache.get() entry found after Reset")
+	}
+	c.add(&pcPredictionCacheEntry{key: k(1), watt: 1})
+	c.add(&pcPredictionCacheEntry{key: k(2), watt: 2})
+	if got, want := c.Stats(), (PCPredictionCacheStats{Hits: 1, Misses: 1, Len: 1}); got != want {
+		t.Errorf("PCPredictionCache.Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCachedPCPredictor_bounds(t *testing.T) {
+	inner := &ErrorBoundsPCPredictor{Predictor: testPCPredictorV1{}, AbsoluteError: 1}
+	p := &CachedPCPredictor{NodeName: "n0", Predictor: inner, Cache: NewPCPredictionCache(0, 0)}
+	want := &BatchPrediction{Watts: []float64{5, 10}, Lower: []float64{4, 9}, Upper: []float64{6, 11}}
+	// the second call is served from the cache, the third one partially
+	for _, requests := range [][]ResourceRequest{{{CPUMilli: 500}, {CPUMilli: 1000}}, {{CPUMilli: 500}, {CPUMilli: 1000}}, {{CPUMilli: 500}, {CPUMilli: 1500}}} {
+		got, err := p.PredictBatchDetails(context.Background(), requests, NewNodeStatus())
+		if err != nil {
+			t.Fatalf("CachedPCPredictor.PredictBatchDetails() error = %v", err)
+		}
+		if requests[1].CPUMilli == 1500 {
+			want = &BatchPrediction{Watts: []float64{5, 15}, Lower: []float64{4, 14}, Upper: []float64{6, 16}}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CachedPCPredictor.PredictBatchDetails() = %+v, want %+v", got, want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+type EnsembleStrategy string
+
+const (
+	EnsembleStrategyMean         EnsembleStrategy = "Mean"
+	EnsembleStrategyWeightedMean EnsembleStrategy = "WeightedMean"
+	EnsembleStrategyMin          EnsembleStrategy = "Min"
+	EnsembleStrategyMax          EnsembleStrategy = "Max"
+)
+
+// EnsemblePCPredictor calls Members concurrently and combines their predictions with Strategy,
+// e.g. to canary a new model version side by side with the current one.
+//
+// Members that fail (errors, time out or return +Inf or NaN) are excluded from the combination,
+// so the ensemble fails only if all the members fail.
+type EnsemblePCPredictor struct {
+	// Strategy defaults to EnsembleStrategyMean.
+	Strategy EnsembleStrategy
+	Members  []EnsemblePCPredictorMember
+}
+
+type EnsemblePCPredictorMember struct {
+	// Name is used in logs and errors, e.g. "model1-v0.2.0"
+	Name      string
+	Predictor PowerConsumptionPredictor
+	// Weight is used by EnsembleStrategyWeightedMean.
+	Weight float64
+	// Timeout is applied to each call if positive.
+	Timeout time.Duration
+}
+
+var _ PowerConsumptionPredictor = (*EnsemblePCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*EnsemblePCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*EnsemblePCPredictor)(nil)
+var _ DetailedPCPredictor = (*EnsemblePCPredictor)(nil)
+var _ NodeStatusConsumer = (*EnsemblePCPredictor)(nil)
+var _ io.Closer = (*EnsemblePCPredictor)(nil)
+
+// NewEnsemblePCPredictor validates the given strategy and members.
+func NewEnsemblePCPredictor(strategy EnsembleStrategy, members []EnsemblePCPredictorMember) (*EnsemblePCPredictor, error) {
+	switch strategy {
+	case "":
+		strategy = EnsembleStrategyMean
+	case EnsembleStrategyMean, EnsembleStrategyWeightedMean, EnsembleStrategyMin, EnsembleStrategyMax:
+	default:
+		return nil, fmt.Errorf("unknown ensemble strategy %q (%w)", strategy, ErrPCPredictor)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("ensemble must have at least one member (%w)", ErrPCPredictor)
+	}
+	for i, m := range members {
+		if m.Predictor == nil {
+			return nil, fmt.Errorf("members[%d] name=%s has no predictor (%w)", i, m.Name, ErrPCPredictor)
+		}
+		if strategy == EnsembleStrategyWeightedMean && (m.Weight < 0 || math.IsNaN(m.Weight) || math.IsInf(m.Weight, 0)) {
+			return nil, fmt.Errorf("members[%d] name=%s has an invalid weight %v (%w)", i, m.Name, m.Weight, ErrPCPredictor)
+		}
+	}
+	return &EnsemblePCPredictor{Strategy: strategy, Members: members}, nil
+}
+
+func (p *EnsemblePCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	return p.PredictResources(ctx, ResourceRequest{CPUMilli: requestCPUMilli}, status)
+}
+
+func (p *EnsemblePCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	watts, err := p.PredictBatch(ctx, []ResourceRequest{request}, status)
+	if err != nil {
+		return math.MaxFloat64, err
+	}
+	return watts[0], nil
+}
+
+func (p *EnsemblePCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	pred, err := p.PredictBatchDetails(ctx, requests, status)
+	if err != nil {
+		return nil, err
+	}
+	return pred.Watts, nil
+}
+
+// PredictBatchDetails calls all the members concurrently and combines the predictions for each request.
+// If any of the members reports the bounds, the bounds are combined in the same way,
+// the predictions are used as the bounds of the members that do not report them.
+func (p *EnsemblePCPredictor) PredictBatchDetails(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error) {
+	if len(p.Members) == 0 {
+		return nil, ErrPCPredictorNotFound
+	}
+
+	results := make([]*BatchPrediction, len(p.Members))
+	errs := make([]error, len(p.Members))
+	var wg sync.WaitGroup
+	for i := range p.Members {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m := &p.Members[i]
+			results[i], errs[i] = predictBatchWithTimeout(ctx, m.Predictor, m.Timeout, requests, status)
+		}(i)
+	}
+	wg.Wait()
+
+	var ok []int
+	var msgs []string
+	hasInterval := false
+	for i, err := range errs {
+		if err != nil {
+			lg.Debug().Msgf("EnsemblePCPredictor.Members[%d] name=%s failed err=%v", i, p.Members[i].Name, err)
+			msgs = append(msgs, fmt.Sprintf("%s: %v", p.Members[i].Name, err))
+			continue
+		}
+		ok = append(ok, i)
+		hasInterval = hasInterval || results[i].HasInterval()
+	}
+	if len(ok) == 0 {
+		return nil, fmt.Errorf("all members failed (%w): %s", ErrPCPredictor, strings.Join(msgs, "; "))
+	}
+
+	pred := &BatchPrediction{Watts: make([]float64, len(requests))}
+	if hasInterval {
+		pred.Lower = make([]float64, len(requests))
+		pred.Upper = make([]float64, len(requests))
+	}
+	for j := range requests {
+		v, err := p.combine(ok, func(i int) float64 { return results[i].Watts[j] })
+		if err != nil {
+			return nil, err
+		}
+		pred.Watts[j] = v
+		if !hasInterval {
+			continue
+		}
+		pred.Lower[j], _ = p.combine(ok, func(i int) float64 {
+			if !results[i].HasInterval() {
+				return results[i].Watts[j]
+			}
+			return results[i].Lower[j]
+		})
+		pred.Upper[j], _ = p.combine(ok, func(i int) float64 {
+			if !results[i].HasInterval() {
+				return results[i].Watts[j]
+			}
+			return results[i].Upper[j]
+		})
+	}
+	return pred, nil
+}
+
+// combine combines value(i) of the members ok with Strategy.
+func (p *EnsemblePCPredictor) combine(ok []int, value func(i int) float64) (float64, error) {
+	switch p.Strategy {
+	case "", EnsembleStrategyMean:
+		var sum float64
+		for _, i := range ok {
+			sum += value(i)
+		}
+		return sum / float64(len(ok)), nil
+	case EnsembleStrategyWeightedMean:
+		var sum, weights float64
+		for _, i := range ok {
+			sum += p.Members[i].Weight * value(i)
+			weights += p.Members[i].Weight
+		}
+		if weights == 0 {
+			return 0.0, fmt.Errorf("sum of the weights of the available members is 0 (%w)", ErrPCPredictor)
+		}
+		return sum / weights, nil
+	case EnsembleStrategyMin:
+		v := math.Inf(1)
+		for _, i := range ok {
+			v = math.Min(v, value(i))
+		}
+		return v, nil
+	case EnsembleStrategyMax:
+		v := math.Inf(-1)
+		for _, i := range ok {
+			v = math.Max(v, value(i))
+		}
+		return v, nil
+	default:
+		return 0.0, fmt.Errorf("unknown ensemble strategy %q (%w)", p.Strategy, ErrPCPredictor)
+	}
+}
+
+// ConsumedNodeStatusKeys returns the union of the keys consumed by Members.
+func (p *EnsemblePCPredictor) ConsumedNodeStatusKeys() []NodeStatusKey {
+	ps := make([]PowerConsumptionPredictor, len(p.Members))
+	for i, m := range p.Members {
+		ps[i] = m.Predictor
+	}
+	return consumedNodeStatusKeys(ps...)
+}
+
+// Close closes the members that implement io.Closer, the first error is returned.
+func (p *EnsemblePCPredictor) Close() error {
+	var err error
+	for _, m := range p.Members {
+		if c, ok := m.Predictor.(io.Closer); ok {
+			if cerr := c.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,130 @@
+package estimator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEnsemblePCPredictor_PredictBatch(t *testing.T) {
+	requests := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 500}, {CPUMilli: 1000}}
+	// returns cpuMilli/100
+	v1 := testPCPredictorV1{}
+	// returns 10+cpuMilli/50
+	v2 := &FakePCPredictor{PredictFunc: func(_ context.Context, mcpu int, _ *NodeStatus) (float64, error) {
+		return 10 + float64(mcpu)/50, nil
+	}}
+	failing := &FakePCPredictor{PredictFunc: func(context.Context, int, *NodeStatus) (float64, error) {
+		return 0.0, ErrPCPredictor
+	}}
+
+	tests := []struct {
+		name     string
+		strategy EnsembleStrategy
+		members  []EnsemblePCPredictorMember
+		want     []float64
+		wantErr  bool
+	}{
+		{"mean", EnsembleStrategyMean, []EnsemblePCPredictorMember{
+			{Name: "v1", Predictor: v1},
+			{Name: "v2", Predictor: v2},
+		}, []float64{5, 12.5, 20}, false},
+		{"weighted_mean", EnsembleStrategyWeightedMean, []EnsemblePCPredictorMember{
+			{Name: "v1", Predictor: v1, Weight: 3},
+			{Name: "v2", Predictor: v2, Weight: 1},
+		}, []float64{2.5, 8.75, 15}, false},
+		{"min", EnsembleStrategyMin, []EnsemblePCPredictorMember{
+			{Name: "v1", Predictor: v1},
+			{Name: "v2", Predictor: v2},
+		}, []float64{0, 5, 10}, false},
+		{"max", EnsembleStrategyMax, []EnsemblePCPredictorMember{
+			{Name: "v1", Predictor: v1},
+			{Name: "v2", Predictor: v2},
+		}, []float64{10, 20, 30}, false},
+		{"failed_member_excluded", EnsembleStrategyWeightedMean, []EnsemblePCPredictorMember{
+			{Name: "v1", Predictor: v1, Weight: 9},
+			{Name: "v2", Predictor: failing, Weight: 1},
+		}, []float64{0, 5, 10}, false},
+		{"all_failed", EnsembleStrategyMean, []EnsemblePCPredictorMember{
+			{Name: "v1", Predictor: failing},
+			{Name: "v2", Predictor: failing},
+		}, nil, true},
+		{"zero_weights", EnsembleStrategyWeightedMean, []EnsemblePCPredictorMember{
+			{Name: "v1", Predictor: v1},
+		}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewEnsemblePCPredictor(tt.strategy, tt.members)
+			if err != nil {
+				t.Fatalf("NewEnsemblePCPredictor() error = %v", err)
+			}
+			got, err := p.PredictBatch(context.Background(), requests, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnsemblePCPredictor.PredictBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrPCPredictor) {
+				t.Errorf("EnsemblePCPredictor.PredictBatch() error = %v, want ErrPCPredictor", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EnsemblePCPredictor.PredictBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEnsemblePCPredictor(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy EnsembleStrategy
+		members  []EnsemblePCPredictorMember
+		want     EnsembleStrategy
+		wantErr  bool
+	}{
+		{"default", "", []EnsemblePCPredictorMember{{Name: "v1", Predictor: testPCPredictorV1{}}}, EnsembleStrategyMean, false},
+		{"max", EnsembleStrategyMax, []EnsemblePCPredictorMember{{Name: "v1", Predictor: testPCPredictorV1{}}}, EnsembleStrategyMax, false},
+		{"unknown", "Median", []EnsemblePCPredictorMember{{Name: "v1", Predictor: testPCPredictorV1{}}}, "", true},
+		{"no_members", EnsembleStrategyMean, nil, "", true},
+		{"nil_predictor", EnsembleStrategyMean, []EnsemblePCPredictorMember{{Name: "v1"}}, "", true},
+		{"negative_weight", EnsembleStrategyWeightedMean, []EnsemblePCPredictorMember{{Name: "v1", Predictor: testPCPredictorV1{}, Weight: -1}}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewEnsemblePCPredictor(tt.strategy, tt.members)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEnsemblePCPredictor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Strategy != tt.want {
+				t.Errorf("NewEnsemblePCPredictor().Strategy = %v, want %v", got.Strategy, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsemblePCPredictor_PredictBatchDetails(t *testing.T) {
+	requests := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 500}, {CPUMilli: 1000}}
+	// returns 10+cpuMilli/50 without the bounds
+	v2 := &FakePCPredictor{PredictFunc: func(_ context.Context, mcpu int, _ *NodeStatus) (float64, error) {
+		return 10 + float64(mcpu)/50, nil
+	}}
+	p, err := NewEnsemblePCPredictor(EnsembleStrategyMean, []EnsemblePCPredictorMember{
+		{Name: "v1", Predictor: &ErrorBoundsPCPredictor{Predictor: testPCPredictorV1{}, AbsoluteError: 2}},
+		{Name: "v2", Predictor: v2},
+	})
+	if err != nil {
+		t.Fatalf("NewEnsemblePCPredictor() error = %v", err)
+	}
+	got, err := p.PredictBatchDetails(context.Background(), requests, nil)
+	if err != nil {
+		t.Fatalf("EnsemblePCPredictor.PredictBatchDetails() error = %v", err)
+	}
+	want := &BatchPrediction{
+		Watts: []float64{5, 12.5, 20},
+		Lower: []float64{4, 11.5, 19},
+		Upper: []float64{6, 13.5, 21},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EnsemblePCPredictor.PredictBatchDetails() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrorBoundsPCPredictor adds the known error bounds of Predictor to its predictions,
+//
+//	lower = watt - (AbsoluteError + RelativeError * |watt|)
+//	upper = watt + (AbsoluteError + RelativeError * |watt|)
+//
+// If Predictor reports its own bounds, they are widened in the same way.
+type ErrorBoundsPCPredictor struct {
+	Predictor PowerConsumptionPredictor
+	// AbsoluteError in watts, e.g. 5
+	AbsoluteError float64
+	// RelativeError in ratio of the predictions, e.g. 0.05
+	RelativeError float64
+}
+
+var _ PowerConsumptionPredictor = (*ErrorBoundsPCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*ErrorBoundsPCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*ErrorBoundsPCPredictor)(nil)
+var _ DetailedPCPredictor = (*ErrorBoundsPCPredictor)(nil)
+var _ NodeStatusConsumer = (*ErrorBoundsPCPredictor)(nil)
+var _ io.Closer = (*ErrorBoundsPCPredictor)(nil)
+
+// NewErrorBoundsPCPredictor validates the given errors, they must be finite and not negative.
+func NewErrorBoundsPCPredictor(p PowerConsumptionPredictor, absoluteError, relativeError float64) (*ErrorBoundsPCPredictor, error) {
+	for _, v := range []float64{absoluteError, relativeError} {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("invalid error bounds absoluteError=%v relativeError=%v (%w)", absoluteError, relativeError, ErrPCPredictor)
+		}
+	}
+	return &ErrorBoundsPCPredictor{Predictor: p, AbsoluteError: absoluteError, RelativeError: relativeError}, nil
+}
+
+func (p *ErrorBoundsPCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	if p.Predictor == nil {
+		return 0.0, ErrPCPredictorNotFound
+	}
+	return p.Predictor.Predict(ctx, requestCPUMilli, status)
+}
+
+func (p *ErrorBoundsPCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	if p.Predictor == nil {
+		return 0.0, ErrPCPredictorNotFound
+	}
+	return ToPCPredictorV2(p.Predictor).PredictResources(ctx, request, status)
+}
+
+func (p *ErrorBoundsPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	if p.Predictor == nil {
+		return nil, ErrPCPredictorNotFound
+	}
+	return PredictBatch(ctx, p.Predictor, requests, status)
+}
+
+// PredictBatchDetails returns the predictions of Predictor with the bounds.
+func (p *ErrorBoundsPCPredictor) PredictBatchDetails(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error) {
+	if p.Predictor == nil {
+		return nil, ErrPCPredictorNotFound
+	}
+	pred, err := PredictBatchDetails(ctx, p.Predictor, requests, status)
+	if err != nil {
+		return nil, err
+	}
+	lower := make([]float64, len(pred.Watts))
+	upper := make([]float64, len(pred.Watts))
+	for i, w := range pred.Watts {
+		lower[i], upper[i] = w, w
+		if pred.HasInterval() {
+			lower[i], upper[i] = pred.Lower[i], pred.Upper[i]
+		}
+		e := p.AbsoluteError + p.RelativeError*math.Abs(w)
+		lower[i] -= e
+		upper[i] += e
+	}
+	pred.Lower, pred.Upper = lower, upper
+	return pred, nil
+}
+
+// ConsumedNodeStatusKeys returns the keys consumed by Predictor.
+func (p *ErrorBoundsPCPredictor) ConsumedNodeStatusKeys() []NodeStatusKey {
+	return consumedNodeStatusKeys(p.Predictor)
+}
+
+// Close closes Predictor if it implements io.Closer.
+func (p *ErrorBoundsPCPredictor) Close() error {
+	if c, ok := p.Predictor.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
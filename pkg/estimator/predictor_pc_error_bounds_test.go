@@ -0,0 +1,130 @@
+package estimator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewErrorBoundsPCPredictor(t *testing.T) {
+	tests := []struct {
+		name          string
+		absoluteError float64
+		relativeError float64
+		wantErr       bool
+	}{
+		{"ok", 5, 0.05, false},
+		{"zero", 0, 0, false},
+		{"negative", -1, 0, true},
+		{"inf", 0, math.Inf(1), true},
+		{"nan", math.NaN(), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewErrorBoundsPCPredictor(testPCPredictorV1{}, tt.absoluteError, tt.relativeError)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewErrorBoundsPCPredictor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrPCPredictor) {
+				t.Errorf("NewErrorBoundsPCPredictor() error = %v, want ErrPCPredictor", err)
+			}
+		})
+	}
+}
+
+func TestErrorBoundsPCPredictor_PredictBatchDetails(t *testing.T) {
+	requests := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 500}, {CPUMilli: 1000}}
+	tests := []struct {
+		name    string
+		p       *ErrorBoundsPCPredictor
+		want    *BatchPrediction
+		wantErr bool
+	}{
+		{"absolute_relative", &ErrorBoundsPCPredictor{Predictor: testPCPredictorV1{}, AbsoluteError: 1, RelativeError: 0.1}, &BatchPrediction{
+			Watts: []float64{0, 5, 10},
+			Lower: []float64{-1, 3.5, 8},
+			Upper: []float64{1, 6.5, 12},
+		}, false},
+		{"nested", &ErrorBoundsPCPredictor{Predictor: &ErrorBoundsPCPredictor{Predictor: testPCPredictorV1{}, AbsoluteError: 1}, AbsoluteError: 2}, &BatchPrediction{
+			Watts: []float64{0, 5, 10},
+			Lower: []float64{-3, 2, 7},
+			Upper: []float64{3, 8, 13},
+		}, false},
+		{"source", &ErrorBoundsPCPredictor{Predictor: &FallbackPCPredictor{Predictors: []FallbackPCPredictorItem{{Name: "PowerCurve", Predictor: testPCPredictorV1{}}}}}, &BatchPrediction{
+			Watts:  []float64{0, 5, 10},
+			Lower:  []float64{0, 5, 10},
+			Upper:  []float64{0, 5, 10},
+			Source: "PowerCurve",
+		}, false},
+		{"nil", &ErrorBoundsPCPredictor{AbsoluteError: 1}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.PredictBatchDetails(context.Background(), requests, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ErrorBoundsPCPredictor.PredictBatchDetails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ErrorBoundsPCPredictor.PredictBatchDetails() = %+v, want %+v", got, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			watts, err := tt.p.PredictBatch(context.Background(), requests, nil)
+			if err != nil || !reflect.DeepEqual(watts, tt.want.Watts) {
+				t.Errorf("ErrorBoundsPCPredictor.PredictBatch() = %v, %v, want %v", watts, err, tt.want.Watts)
+			}
+		})
+	}
+}
+
+func TestEstimator_EstimatePowerConsumption_bounds(t *testing.T) {
+	double := &FakePCPredictor{PredictFunc: func(_ context.Context, mcpu int, _ *NodeStatus) (float64, error) {
+		return float64(mcpu) / 50, nil
+	}}
+	ptr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name           string
+		n0, n1         PowerConsumptionPredictor
+		wantLower      []float64
+		wantUpper      []float64
+		wantGroupLower *float64
+		wantGroupUpper *float64
+	}{
+		{"no_bounds", testPCPredictorV1{}, double, nil, nil, nil, nil},
+		{"chosen_node", &ErrorBoundsPCPredictor{Predictor: testPCPredictorV1{}, AbsoluteError: 1}, double,
+			[]float64{4, 9}, []float64{6, 11}, ptr(9), ptr(11)},
+		{"other_node", testPCPredictorV1{}, &ErrorBoundsPCPredictor{Predictor: double, AbsoluteError: 1},
+			[]float64{5, 10}, []float64{5, 10}, ptr(10), ptr(10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est := &Estimator{Nodes: &Nodes{}}
+			est.Nodes.Add("n0", NewNode("n0", nil, time.Second, tt.n0))
+			est.Nodes.Add("n1", NewNode("n1", nil, time.Second, tt.n1))
+			defer est.stop()
+
+			got, err := est.EstimatePowerConsumption(context.Background(), 500, 2)
+			if err != nil {
+				t.Fatalf("Estimator.EstimatePowerConsumption() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.WattIncreases, []float64{5, 10}) ||
+				!reflect.DeepEqual(got.WattIncreasesLower, tt.wantLower) || !reflect.DeepEqual(got.WattIncreasesUpper, tt.wantUpper) {
+				t.Errorf("Estimator.EstimatePowerConsumption() = %+v, want WattIncreasesLower=%v WattIncreasesUpper=%v", got, tt.wantLower, tt.wantUpper)
+			}
+
+			gotGroups, err := est.EstimatePowerConsumptionGroups(context.Background(), []WorkloadGroup{{CpuMilli: 500, Count: 2}})
+			if err != nil {
+				t.Fatalf("Estimator.EstimatePowerConsumptionGroups() error = %v", err)
+			}
+			if gotGroups.WattIncrease != 10 ||
+				!reflect.DeepEqual(gotGroups.WattIncreaseLower, tt.wantGroupLower) || !reflect.DeepEqual(gotGroups.WattIncreaseUpper, tt.wantGroupUpper) {
+				t.Errorf("Estimator.EstimatePowerConsumptionGroups() = %+v, want WattIncreaseLower=%v WattIncreaseUpper=%v", gotGroups, tt.wantGroupLower, tt.wantGroupUpper)
+			}
+		})
+	}
+}
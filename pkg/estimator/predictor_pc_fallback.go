@@ -0,0 +1,118 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// FallbackPCPredictor tries Predictors in order and returns the first predictions without errors,
+// predictions including +Inf or NaN are treated as errors.
+type FallbackPCPredictor struct {
+	Predictors []FallbackPCPredictorItem
+}
+
+type FallbackPCPredictorItem struct {
+	// Name is reported as the source of the predictions, e.g. "MLServer"
+	Name      string
+	Predictor PowerConsumptionPredictor
+	// Timeout is applied to each call if positive.
+	Timeout time.Duration
+}
+
+var _ PowerConsumptionPredictor = (*FallbackPCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*FallbackPCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*FallbackPCPredictor)(nil)
+var _ DetailedPCPredictor = (*FallbackPCPredictor)(nil)
+var _ NodeStatusConsumer = (*FallbackPCPredictor)(nil)
+var _ io.Closer = (*FallbackPCPredictor)(nil)
+
+func (p *FallbackPCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	return p.PredictResources(ctx, ResourceRequest{CPUMilli: requestCPUMilli}, status)
+}
+
+func (p *FallbackPCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	pred, err := p.PredictBatchDetails(ctx, []ResourceRequest{request}, status)
+	if err != nil {
+		return math.MaxFloat64, err
+	}
+	return pred.Watts[0], nil
+}
+
+func (p *FallbackPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	pred, err := p.PredictBatchDetails(ctx, requests, status)
+	if err != nil {
+		return nil, err
+	}
+	return pred.Watts, nil
+}
+
+// PredictBatchDetails returns the predictions with the name of the predictor that produced them as Source,
+// the errors of all the predictors are returned if none of them succeeded.
+func (p *FallbackPCPredictor) PredictBatchDetails(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error) {
+	if len(p.Predictors) == 0 {
+		return nil, ErrPCPredictorNotFound
+	}
+	var errs []string
+	for i, item := range p.Predictors {
+		pred, err := predictBatchWithTimeout(ctx, item.Predictor, item.Timeout, requests, status)
+		if err == nil {
+			pred.Source = item.Name
+			return pred, nil
+		}
+		lg.Debug().Msgf("FallbackPCPredictor.Predictors[%d] name=%s failed err=%v", i, item.Name, err)
+		errs = append(errs, fmt.Sprintf("%s: %v", item.Name, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("all predictors failed (%w): %s", ErrPCPredictor, strings.Join(errs, "; "))
+}
+
+// predictBatchWithTimeout calls PredictBatchDetails with the timeout if positive,
+// predictions including +Inf or NaN are returned as errors.
+func predictBatchWithTimeout(ctx context.Context, p PowerConsumptionPredictor, timeout time.Duration, requests []ResourceRequest, status *NodeStatus) (*BatchPrediction, error) {
+	if p == nil {
+		return nil, ErrPCPredictorNotFound
+	}
+	if timeout > 0 {
+		var cncl context.CancelFunc
+		ctx, cncl = context.WithTimeout(ctx, timeout)
+		defer cncl()
+	}
+	pred, err := PredictBatchDetails(ctx, p, requests, status)
+	if err != nil {
+		return nil, err
+	}
+	for i, w := range pred.Watts {
+		if math.IsInf(w, 0) || math.IsNaN(w) {
+			return nil, fmt.Errorf("invalid prediction watts[%d]=%v (%w)", i, w, ErrPCPredictor)
+		}
+	}
+	return pred, nil
+}
+
+// ConsumedNodeStatusKeys returns the union of the keys consumed by Predictors.
+func (p *FallbackPCPredictor) ConsumedNodeStatusKeys() []NodeStatusKey {
+	ps := make([]PowerConsumptionPredictor, len(p.Predictors))
+	for i, item := range p.Predictors {
+		ps[i] = item.Predictor
+	}
+	return consumedNodeStatusKeys(ps...)
+}
+
+// Close closes the predictors that implement io.Closer, the first error is returned.
+func (p *FallbackPCPredictor) Close() error {
+	var err error
+	for _, item := range p.Predictors {
+		if c, ok := item.Predictor.(io.Closer); ok {
+			if cerr := c.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+	}
+	return err
+}
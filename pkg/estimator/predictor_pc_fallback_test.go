@@ -0,0 +1,115 @@
+package estimator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFallbackPCPredictor_PredictBatchDetails(t *testing.T) {
+	requests := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 500}, {CPUMilli: 1000}}
+	failing := &FakePCPredictor{PredictFunc: func(context.Context, int, *NodeStatus) (float64, error) {
+		return 0.0, ErrPCPredictor
+	}}
+	inf := &FakePCPredictor{PredictFunc: func(context.Context, int, *NodeStatus) (float64, error) {
+		return math.Inf(1), nil
+	}}
+	slow := &FakePCPredictor{PredictFunc: func(ctx context.Context, _ int, _ *NodeStatus) (float64, error) {
+		select {
+		case <-ctx.Done():
+			return 0.0, ctx.Err()
+		case <-time.After(time.Second):
+			return 1.0, nil
+		}
+	}}
+	double := &FakePCPredictor{PredictFunc: func(_ context.Context, mcpu int, _ *NodeStatus) (float64, error) {
+		return float64(mcpu) / 50, nil
+	}}
+
+	tests := []struct {
+		name       string
+		predictors []FallbackPCPredictorItem
+		want       []float64
+		wantSource string
+		wantErr    bool
+	}{
+		{"first", []FallbackPCPredictorItem{
+			{Name: "a", Predictor: testPCPredictorV1{}},
+			{Name: "b", Predictor: double},
+		}, []float64{0, 5, 10}, "a", false},
+		{"error", []FallbackPCPredictorItem{
+			{Name: "a", Predictor: failing},
+			{Name: "b", Predictor: double},
+		}, []float64{0, 10, 20}, "b", false},
+		{"inf", []FallbackPCPredictorItem{
+			{Name: "a", Predictor: inf},
+			{Name: "b", Predictor: testPCPredictorV1{}},
+		}, []float64{0, 5, 10}, "b", false},
+		{"timeout", []FallbackPCPredictorItem{
+			{Name: "a", Predictor: slow, Timeout: 10 * time.Millisecond},
+			{Name: "b", Predictor: testPCPredictorV1{}},
+		}, []float64{0, 5, 10}, "b", false},
+		{"nil", []FallbackPCPredictorItem{
+			{Name: "a"},
+			{Name: "b", Predictor: &testBatchPCPredictor{}},
+		}, []float64{0, 5, 10}, "b", false},
+		{"all_failed", []FallbackPCPredictorItem{
+			{Name: "a", Predictor: failing},
+			{Name: "b", Predictor: inf},
+		}, nil, "", true},
+		{"empty", nil, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &FallbackPCPredictor{Predictors: tt.predictors}
+			var got []float64
+			var source string
+			pred, err := p.PredictBatchDetails(context.Background(), requests, nil)
+			if pred != nil {
+				got, source = pred.Watts, pred.Source
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FallbackPCPredictor.PredictBatchDetails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrPCPredictor) && !errors.Is(err, ErrPCPredictorNotFound) {
+				t.Errorf("FallbackPCPredictor.PredictBatchDetails() error = %v, want ErrPCPredictor", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || source != tt.wantSource {
+				t.Errorf("FallbackPCPredictor.PredictBatchDetails() = %v, %v, want %v, %v", got, source, tt.want, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestEstimator_EstimatePowerConsumption_predictors(t *testing.T) {
+	failing := &FakePCPredictor{PredictFunc: func(context.Context, int, *NodeStatus) (float64, error) {
+		return 0.0, ErrPCPredictor
+	}}
+	est := &Estimator{Nodes: &Nodes{}}
+	est.Nodes.Add("n0", NewNode("n0", nil, time.Second, &FallbackPCPredictor{Predictors: []FallbackPCPredictorItem{
+		{Name: "MLServer", Predictor: failing},
+		{Name: "PowerCurve", Predictor: testPCPredictorV1{}},
+	}}))
+	est.Nodes.Add("n1", NewNode("n1", nil, time.Second, testPCPredictorV1{}))
+	defer est.stop()
+
+	got, err := est.EstimatePowerConsumption(context.Background(), 500, 2)
+	if err != nil {
+		t.Fatalf("Estimator.EstimatePowerConsumption() error = %v", err)
+	}
+	want := map[string]string{"n0": "PowerCurve"}
+	if !reflect.DeepEqual(got.Predictors, want) || !reflect.DeepEqual(got.WattIncreases, []float64{5, 10}) {
+		t.Errorf("Estimator.EstimatePowerConsumption() = %+v, want Predictors=%v", got, want)
+	}
+
+	gotGroups, err := est.EstimatePowerConsumptionGroups(context.Background(), []WorkloadGroup{{CpuMilli: 500, Count: 2}})
+	if err != nil {
+		t.Fatalf("Estimator.EstimatePowerConsumptionGroups() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotGroups.Predictors, want) {
+		t.Errorf("Estimator.EstimatePowerConsumptionGroups() = %+v, want Predictors=%v", gotGroups, want)
+	}
+}
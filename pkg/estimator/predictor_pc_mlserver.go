@@ -23,9 +23,123 @@ type MLServerPCPredictor struct {
 	// Version specifies version for the model
 	// e.g. "v0.1.0"
 	Version string
+	// HTTPClient sends the requests, http.DefaultClient is used if nil.
+	HTTPClient *HTTPClient
+
+	V2TensorSpec
+}
+
+// V2TensorSpec specifies the tensors of the V2 inference protocol,
+// shared by MLServerPCPredictor (REST) and MLServerGRPCPCPredictor (gRPC).
+// TFServingPCPredictor also uses it to build the instances.
+type V2TensorSpec struct {
+	// Features specifies NodeStatusKeys used as the columns of the input tensor in order,
+	// defaults to DefaultMLServerFeatures.
+	// The requested CPU is added to NodeStatusCPUUsage, and the request features
+	// (FeatureRequestMemoryBytes and FeatureRequestExtendedResourcePrefix) are filled with the requested resources.
+	Features []NodeStatusKey
+	// InputName specifies the name of the input tensor, defaults to "predict-prob".
+	InputName string
+	// Datatype specifies the datatype of the input tensor, defaults to "FP32".
+	Datatype string
+	// OutputIndex specifies the output tensor used as the prediction, defaults to 0.
+	OutputIndex int
+}
+
+var DefaultMLServerFeatures = []NodeStatusKey{NodeStatusCPUUsage, NodeStatusAmbientTemp, NodeStatusStaticPressureDiff}
+
+const (
+	defaultMLServerInputName = "predict-prob"
+	defaultMLServerDatatype  = "FP32"
+)
+
+func (s *V2TensorSpec) inputName() string {
+	if s.InputName == "" {
+		return defaultMLServerInputName
+	}
+	return s.InputName
+}
+
+func (s *V2TensorSpec) datatype() string {
+	if s.Datatype == "" {
+		return defaultMLServerDatatype
+	}
+	return s.Datatype
+}
+
+// ConsumedNodeStatusKeys returns Features except the request features,
+// and NodeStatusLogicalProcessors if NodeStatusCPUUsage is in Features.
+func (s *V2TensorSpec) ConsumedNodeStatusKeys() []NodeStatusKey {
+	features := s.Features
+	if len(features) == 0 {
+		features = DefaultMLServerFeatures
+	}
+	var keys []NodeStatusKey
+	for _, k := range features {
+		if isRequestFeature(k) {
+			continue
+		}
+		keys = append(keys, k)
+		if k == NodeStatusCPUUsage {
+			keys = append(keys, NodeStatusLogicalProcessors)
+		}
+	}
+	return uniqueNodeStatusKeys(keys)
+}
+
+// buildInputs returns inputs[len(requests)][len(Features)].
+func (s *V2TensorSpec) buildInputs(requests []ResourceRequest, status *NodeStatus) ([][]float64, error) {
+	features := s.Features
+	if len(features) == 0 {
+		features = DefaultMLServerFeatures
+	}
+
+	base := make([]float64, len(features))
+	cpuUsageIdx := -1
+	var requestIdxs []int
+	for i, k := range features {
+		if isRequestFeature(k) {
+			requestIdxs = append(requestIdxs, i)
+			continue
+		}
+		v, err := status.GetFloat(k)
+		if err != nil {
+			return nil, fmt.Errorf("could not get %s (%w): %v", k, ErrPCPredictor, err)
+		}
+		base[i] = v
+		if k == NodeStatusCPUUsage {
+			cpuUsageIdx = i
+		}
+	}
+
+	// the request is ignored if cpuUsage is not in the features
+	totalCPUMilli := 0
+	if cpuUsageIdx >= 0 {
+		logicalProcessors, err := status.GetInt(NodeStatusLogicalProcessors)
+		if err != nil {
+			return nil, fmt.Errorf("could not get logical processors (%w): %v", ErrPCPredictor, err)
+		}
+		totalCPUMilli = logicalProcessors * 1000
+	}
+
+	inputs := make([][]float64, len(requests))
+	for i, r := range requests {
+		inputs[i] = make([]float64, len(base))
+		copy(inputs[i], base)
+		if cpuUsageIdx >= 0 {
+			inputs[i][cpuUsageIdx] += float64(r.CPUMilli) / float64(totalCPUMilli)
+		}
+		for _, j := range requestIdxs {
+			inputs[i][j] = r.Feature(features[j])
+		}
+	}
+	return inputs, nil
 }
 
 var _ PowerConsumptionPredictor = (*MLServerPCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*MLServerPCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*MLServerPCPredictor)(nil)
+var _ NodeStatusConsumer = (*MLServerPCPredictor)(nil)
 
 // NewMLServerPCPredictorFromURL parses the given endpoint URL.
 //
@@ -48,31 +162,30 @@ func NewMLServerPCPredictorFromURL(endpoint string) (*MLServerPCPredictor, error
 }
 
 func (p *MLServerPCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	return p.PredictResources(ctx, ResourceRequest{CPUMilli: requestCPUMilli}, status)
+}
 
-	currentCPUUsage, err := NodeStatusGetCPUUsage(status)
-	if err != nil {
-		return math.MaxFloat64, fmt.Errorf("could not get current CPU usage (%w): %v", ErrPCPredictor, err)
-	}
-
-	ambientTemp, err := NodeStatusGetAmbientTemp(status)
+func (p *MLServerPCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	watts, err := p.PredictBatch(ctx, []ResourceRequest{request}, status)
 	if err != nil {
-		return math.MaxFloat64, fmt.Errorf("could not get ambient temp (%w): %v", ErrPCPredictor, err)
+		return math.MaxFloat64, err
 	}
+	return watts[0], nil
+}
 
-	staticPressureDiff, err := NodeStatusGetStaticPressureDiff(status)
-	if err != nil {
-		return math.MaxFloat64, fmt.Errorf("could not get ambient temp (%w): %v", ErrPCPredictor, err)
+// PredictBatch sends all the requests in a [N, len(Features)] tensor,
+// resources other than CPU are used only if the request features are in Features.
+func (p *MLServerPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	if len(requests) == 0 {
+		return []float64{}, nil
 	}
 
-	logicalProcessors, err := NodeStatusGetLogicalProcessors(status)
+	inputs, err := p.buildInputs(requests, status)
 	if err != nil {
-		return math.MaxFloat64, fmt.Errorf("could not get logical processors (%w): %v", ErrPCPredictor, err)
+		return nil, err
 	}
 
-	totalCPUMilli := logicalProcessors * 1000
-	requestCPUUsage := float64(requestCPUMilli) / float64(totalCPUMilli)
-
-	return p.POSTPredictRequest(ctx, currentCPUUsage+requestCPUUsage, ambientTemp, staticPressureDiff)
+	return p.POSTPredictBatchRequest(ctx, inputs)
 }
 
 // getURLV2Infer returns the API endpoint.
@@ -81,21 +194,32 @@ func (p *MLServerPCPredictor) getURLV2Infer() (string, error) {
 	return url.JoinPath(p.Server, "v2", "models", p.Model, "versions", p.Version, "infer")
 }
 
+// POSTPredictRequest sends a single row, this assumes the predictor uses DefaultMLServerFeatures.
 func (p *MLServerPCPredictor) POSTPredictRequest(ctx context.Context, cpuUsage, ambientTemp, staticPressureDiff float64) (float64, error) {
+	watts, err := p.POSTPredictBatchRequest(ctx, [][]float64{{cpuUsage, ambientTemp, staticPressureDiff}})
+	if err != nil {
+		return 0.0, err
+	}
+	return watts[0], nil
+}
+
+// POSTPredictBatchRequest sends inputs[N][len(Features)] in one request
+// and returns N predictions from outputs[OutputIndex].
+func (p *MLServerPCPredictor) POSTPredictBatchRequest(ctx context.Context, inputs [][]float64) ([]float64, error) {
 
 	url, err := p.getURLV2Infer()
 	if err != nil {
-		return 0.0, fmt.Errorf("unable to get endpoint URL: %w", err)
+		return nil, fmt.Errorf("unable to get endpoint URL: %w", err)
 	}
 
-	body, err := json.Marshal(newMLServerPCPredictorRequest(cpuUsage, ambientTemp, staticPressureDiff))
+	body, err := json.Marshal(p.newBatchRequest(inputs))
 	if err != nil {
-		return 0.0, fmt.Errorf("unable to marshal the request body=%+v err=%w", body, err)
+		return nil, fmt.Errorf("unable to marshal the request body=%+v err=%w", body, err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
 	if err != nil {
-		return 0.0, fmt.Errorf("unable to create HTTP request: %w", err)
+		return nil, fmt.Errorf("unable to create HTTP request: %w", err)
 	}
 
 	curl, err := http2curl.GetCurlCommand(req)
@@ -105,22 +229,23 @@ func (p *MLServerPCPredictor) POSTPredictRequest(ctx context.Context, cpuUsage,
 		lg.Trace().Msgf("MLServerPCPredictor.Predict request=%v", curl.String())
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := p.HTTPClient.Do(req)
 	if err != nil {
-		return 0.0, fmt.Errorf("unable to send HTTP request: %w", err)
+		return nil, fmt.Errorf("unable to send HTTP request: %w", err)
 	}
+	defer DrainAndClose(resp.Body)
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var predictResp mlServerPCPredictorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&predictResp); err != nil {
-			return 0.0, fmt.Errorf("could not decode resp: %w", err)
+			return nil, fmt.Errorf("could not decode resp: %w", err)
 		}
-		if len(predictResp.Outputs) == 0 || len(predictResp.Outputs[0].Data) == 0 {
-			return 0.0, fmt.Errorf("invalid response predictResp=%+v", predictResp)
+		if len(predictResp.Outputs) <= p.OutputIndex || len(predictResp.Outputs[p.OutputIndex].Data) != len(inputs) {
+			return nil, fmt.Errorf("invalid response len(inputs)=%d outputIndex=%d predictResp=%+v", len(inputs), p.OutputIndex, predictResp)
 		}
-		return predictResp.Outputs[0].Data[0], nil
+		return predictResp.Outputs[p.OutputIndex].Data, nil
 	default:
-		return 0.0, fmt.Errorf("HTTP status=%v request=%v", resp.Status, curl.String())
+		return nil, fmt.Errorf("HTTP status=%v request=%v", resp.Status, curl.String())
 	}
 
 }
@@ -140,33 +265,40 @@ func (p *MLServerPCPredictor) POSTPredictRequest(ctx context.Context, cpuUsage,
 //	  ]
 //	}
 type mlServerPCPredictorRequest struct {
-	Inputs []struct {
-		Name     string      `json:"name"`
-		Shape    []int       `json:"shape"`
-		Datatype string      `json:"datatype"`
-		Data     [][]float32 `json:"data"`
-	} `json:"inputs"`
+	Inputs []mlServerPCPredictorRequestInput `json:"inputs"`
+}
+
+// mlServerPCPredictorRequestInput holds an input tensor,
+// data is always sent as JSON numbers and the server converts them to the datatype.
+type mlServerPCPredictorRequestInput struct {
+	Name     string      `json:"name"`
+	Shape    []int       `json:"shape"`
+	Datatype string      `json:"datatype"`
+	Data     [][]float64 `json:"data"`
 }
 
 func newMLServerPCPredictorRequest(cpuUsage, ambientTemp, staticPressureDiff float64) *mlServerPCPredictorRequest {
-	const (
-		name     = "predict-prob"
-		datatype = "FP32"
-		shapeX   = 1
-		shapeY   = 3
-	)
+	return newMLServerPCPredictorBatchRequest(defaultMLServerInputName, defaultMLServerDatatype, [][]float64{{cpuUsage, ambientTemp, staticPressureDiff}})
+}
+
+// newBatchRequest returns a request with the input name and the datatype of the predictor.
+func (p *MLServerPCPredictor) newBatchRequest(inputs [][]float64) *mlServerPCPredictorRequest {
+	return newMLServerPCPredictorBatchRequest(p.inputName(), p.datatype(), inputs)
+}
+
+// newMLServerPCPredictorBatchRequest returns a request with shape [len(inputs), len(inputs[0])].
+func newMLServerPCPredictorBatchRequest(name, datatype string, inputs [][]float64) *mlServerPCPredictorRequest {
+	shapeY := 0
+	if len(inputs) > 0 {
+		shapeY = len(inputs[0])
+	}
 	return &mlServerPCPredictorRequest{
-		Inputs: []struct {
-			Name     string      `json:"name"`
-			Shape    []int       `json:"shape"`
-			Datatype string      `json:"datatype"`
-			Data     [][]float32 `json:"data"`
-		}{
+		Inputs: []mlServerPCPredictorRequestInput{
 			{
 				Name:     name,
-				Shape:    []int{shapeX, shapeY},
+				Shape:    []int{len(inputs), shapeY},
 				Datatype: datatype,
-				Data:     [][]float32{{float32(cpuUsage), float32(ambientTemp), float32(staticPressureDiff)}},
+				Data:     inputs,
 			},
 		},
 	}
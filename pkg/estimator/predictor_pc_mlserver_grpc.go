@@ -0,0 +1,238 @@
+package estimator
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Nedopro2022/wao-estimator/pkg/estimator/inference"
+)
+
+// MLServerGRPCPCPredictor predicts with the V2 inference protocol over gRPC (GRPCInferenceService/ModelInfer),
+// which is served by MLServer, Triton and KServe.
+type MLServerGRPCPCPredictor struct {
+	// Target specifies gRPC server address
+	// e.g. "localhost:8081"
+	Target string
+	// TLS enables TLS
+	TLS bool
+	// Model specifies model name
+	// e.g. "model1"
+	Model string
+	// Version specifies version for the model
+	// e.g. "v0.1.0"
+	Version string
+
+	V2TensorSpec
+
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+}
+
+var _ PowerConsumptionPredictor = (*MLServerGRPCPCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*MLServerGRPCPCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*MLServerGRPCPCPredictor)(nil)
+var _ NodeStatusConsumer = (*MLServerGRPCPCPredictor)(nil)
+
+// NewMLServerGRPCPCPredictorFromURL parses the given endpoint URL.
+//
+// Format: {grpc|grpcs}://{Target}/v2/models/{Model}/versions/{Version}/**
+// Example: grpc://hogehoge:8081/v2/models/model1/versions/v0.1.0 -> &{Target: "hogehoge:8081", TLS: false, Model: "model1", Version: "v0.1.0"}
+func NewMLServerGRPCPCPredictorFromURL(endpoint string) (*MLServerGRPCPCPredictor, error) {
+	parsedURL, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse MLServer gRPC endpoint URL %w: %v", ErrPCPredictor, err)
+	}
+	var useTLS bool
+	switch parsedURL.Scheme {
+	case "grpc":
+	case "grpcs":
+		useTLS = true
+	default:
+		return nil, fmt.Errorf("could not parse MLServer gRPC endpoint URL %w: scheme must be grpc or grpcs", ErrPCPredictor)
+	}
+	ss := strings.Split(parsedURL.Path, "/") // "/v2/models/model1/versions/v0.1.0" -> ["", "v2", "models", "model1", "versions", "v0.1.0"]
+	if len(ss) < 6 {
+		return nil, fmt.Errorf("could not parse MLServer gRPC endpoint URL %w: url path must be in {Target}/v2/models/{Model}/versions/{Version}/** format", ErrPCPredictor)
+	}
+	return &MLServerGRPCPCPredictor{
+		Target:  parsedURL.Host,
+		TLS:     useTLS,
+		Model:   ss[3],
+		Version: ss[5],
+	}, nil
+}
+
+func (p *MLServerGRPCPCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	return p.PredictResources(ctx, ResourceRequest{CPUMilli: requestCPUMilli}, status)
+}
+
+func (p *MLServerGRPCPCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	watts, err := p.PredictBatch(ctx, []ResourceRequest{request}, status)
+	if err != nil {
+		return math.MaxFloat64, err
+	}
+	return watts[0], nil
+}
+
+// PredictBatch sends all the requests in a [N, len(Features)] tensor,
+// resources other than CPU are used only if the request features are in Features.
+func (p *MLServerGRPCPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	if len(requests) == 0 {
+		return []float64{}, nil
+	}
+
+	inputs, err := p.buildInputs(requests, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ModelInfer(ctx, inputs)
+}
+
+// ModelInfer sends inputs[N][len(Features)] in one request
+// and returns N predictions from outputs[OutputIndex].
+func (p *MLServerGRPCPCPredictor) ModelInfer(ctx context.Context, inputs [][]float64) ([]float64, error) {
+	conn, err := p.getConn()
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", p.Target, err)
+	}
+
+	tensor, err := newV2InferInputTensor(p.inputName(), p.datatype(), inputs)
+	if err != nil {
+		return nil, err
+	}
+	req := &inference.ModelInferRequest{
+		ModelName:    p.Model,
+		ModelVersion: p.Version,
+		Inputs:       []*inference.ModelInferRequest_InferInputTensor{tensor},
+	}
+	lg.Trace().Msgf("MLServerGRPCPCPredictor.Predict target=%s request=%v", p.Target, req)
+
+	resp, err := inference.NewGRPCInferenceServiceClient(conn).ModelInfer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send gRPC request: %w", err)
+	}
+
+	data, err := v2OutputData(resp, p.OutputIndex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response outputIndex=%d: %w", p.OutputIndex, err)
+	}
+	if len(data) != len(inputs) {
+		return nil, fmt.Errorf("invalid response len(inputs)=%d resp=%v", len(inputs), resp)
+	}
+	return data, nil
+}
+
+// getConn returns the connection, a new connection is created lazily.
+func (p *MLServerGRPCPCPredictor) getConn() (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn != nil {
+		return p.conn, nil
+	}
+	creds := insecure.NewCredentials()
+	if p.TLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.Dial(p.Target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	p.conn = conn
+	return conn, nil
+}
+
+// Close closes the connection, Node calls this when it stops.
+func (p *MLServerGRPCPCPredictor) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+// newV2InferInputTensor returns a tensor with shape [len(inputs), len(inputs[0])].
+func newV2InferInputTensor(name, datatype string, inputs [][]float64) (*inference.ModelInferRequest_InferInputTensor, error) {
+	shapeY := 0
+	if len(inputs) > 0 {
+		shapeY = len(inputs[0])
+	}
+	t := &inference.ModelInferRequest_InferInputTensor{
+		Name:     name,
+		Datatype: datatype,
+		Shape:    []int64{int64(len(inputs)), int64(shapeY)},
+		Contents: &inference.InferTensorContents{},
+	}
+	for _, row := range inputs {
+		for _, v := range row {
+			switch datatype {
+			case "FP32":
+				t.Contents.Fp32Contents = append(t.Contents.Fp32Contents, float32(v))
+			case "FP64":
+				t.Contents.Fp64Contents = append(t.Contents.Fp64Contents, v)
+			default:
+				return nil, fmt.Errorf("datatype %s is not supported over gRPC (%w)", datatype, ErrPCPredictor)
+			}
+		}
+	}
+	return t, nil
+}
+
+// v2OutputData returns outputs[i] as float64, raw_output_contents[i] is used if set.
+func v2OutputData(r *inference.ModelInferResponse, i int) ([]float64, error) {
+	if i < 0 || i >= len(r.Outputs) {
+		return nil, fmt.Errorf("%d outputs", len(r.Outputs))
+	}
+	out := r.Outputs[i]
+	if i < len(r.RawOutputContents) {
+		return decodeV2RawContents(out.Datatype, r.RawOutputContents[i])
+	}
+	contents := out.GetContents()
+	if len(contents.GetFp64Contents()) != 0 {
+		return contents.GetFp64Contents(), nil
+	}
+	data := make([]float64, len(contents.GetFp32Contents()))
+	for j, v := range contents.GetFp32Contents() {
+		data[j] = float64(v)
+	}
+	return data, nil
+}
+
+// decodeV2RawContents decodes little-endian raw contents.
+func decodeV2RawContents(datatype string, b []byte) ([]float64, error) {
+	switch datatype {
+	case "FP32":
+		if len(b)%4 != 0 {
+			return nil, fmt.Errorf("invalid FP32 raw contents len=%d", len(b))
+		}
+		data := make([]float64, len(b)/4)
+		for j := range data {
+			data[j] = float64(math.Float32frombits(binary.LittleEndian.Uint32(b[j*4:])))
+		}
+		return data, nil
+	case "FP64":
+		if len(b)%8 != 0 {
+			return nil, fmt.Errorf("invalid FP64 raw contents len=%d", len(b))
+		}
+		data := make([]float64, len(b)/8)
+		for j := range data {
+			data[j] = math.Float64frombits(binary.LittleEndian.Uint64(b[j*8:]))
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsupported datatype %s", datatype)
+	}
+}
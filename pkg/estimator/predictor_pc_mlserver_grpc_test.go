@@ -0,0 +1,235 @@
+package estimator
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/Nedopro2022/wao-estimator/pkg/estimator/inference"
+)
+
+func TestNewMLServerGRPCPCPredictorFromURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     *MLServerGRPCPCPredictor
+		wantErr  bool
+	}{
+		{"grpc", "grpc://localhost:8081/v2/models/model1/versions/v0.1.0", &MLServerGRPCPCPredictor{
+			Target:  "localhost:8081",
+			Model:   "model1",
+			Version: "v0.1.0",
+		}, false},
+		{"grpcs", "grpcs://10.0.0.1:443/v2/models/model2/versions/v0.2.0/", &MLServerGRPCPCPredictor{
+			Target:  "10.0.0.1:443",
+			TLS:     true,
+			Model:   "model2",
+			Version: "v0.2.0",
+		}, false},
+		{"http", "http://localhost:8080/v2/models/model1/versions/v0.1.0", nil, true},
+		{"no_version", "grpc://localhost:8081/v2/models/model1", nil, true},
+		{"no_scheme", "localhost:8081/v2/models/model1/versions/v0.1.0", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMLServerGRPCPCPredictorFromURL(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewMLServerGRPCPCPredictorFromURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMLServerGRPCPCPredictorFromURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_v2OutputData(t *testing.T) {
+	resp := &inference.ModelInferResponse{
+		Outputs: []*inference.ModelInferResponse_InferOutputTensor{
+			{Name: "fp32", Datatype: "FP32", Shape: []int64{2, 1}, Contents: &inference.InferTensorContents{Fp32Contents: []float32{1.5, 2}}},
+			{Name: "fp64", Datatype: "FP64", Shape: []int64{2, 1}, Contents: &inference.InferTensorContents{Fp64Contents: []float64{94.76267448501928, 15}}},
+			{Name: "empty", Datatype: "FP32", Shape: []int64{0, 1}},
+		},
+	}
+	rawResp := &inference.ModelInferResponse{
+		Outputs:           []*inference.ModelInferResponse_InferOutputTensor{{Name: "raw", Datatype: "FP64", Shape: []int64{1, 1}}},
+		RawOutputContents: [][]byte{{0, 0, 0, 0, 0, 0, 0xf0, 0x3f}},
+	}
+	tests := []struct {
+		name    string
+		resp    *inference.ModelInferResponse
+		i       int
+		want    []float64
+		wantErr bool
+	}{
+		{"fp32", resp, 0, []float64{1.5, 2}, false},
+		{"fp64", resp, 1, []float64{94.76267448501928, 15}, false},
+		{"empty", resp, 2, []float64{}, false},
+		{"raw", rawResp, 0, []float64{1}, false},
+		{"out_of_range", resp, 3, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v2OutputData(tt.resp, tt.i)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("v2OutputData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("v2OutputData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testMLServerGRPC predicts the sum of each row in outputs[0]
+// and the first column of each row in outputs[1], and counts requests.
+// If raw is true the outputs are sent in raw_output_contents as Triton does.
+type testMLServerGRPC struct {
+	inference.UnimplementedGRPCInferenceServiceServer
+	t        *testing.T
+	requests *int32
+	raw      bool
+}
+
+func (s *testMLServerGRPC) ModelInfer(_ context.Context, req *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+	atomic.AddInt32(s.requests, 1)
+	if req.ModelName != "model1" || req.ModelVersion != "v0.1.0" || len(req.Inputs) != 1 {
+		s.t.Errorf("invalid request=%v", req)
+	}
+	in := req.Inputs[0]
+	var flat []float64
+	for _, v := range in.GetContents().GetFp32Contents() {
+		flat = append(flat, float64(v))
+	}
+	flat = append(flat, in.GetContents().GetFp64Contents()...)
+	if len(in.Shape) != 2 || int(in.Shape[0]*in.Shape[1]) != len(flat) {
+		s.t.Errorf("invalid shape=%v len(contents)=%d", in.Shape, len(flat))
+	}
+	n, m := int(in.Shape[0]), int(in.Shape[1])
+	data := make([]float64, n)
+	data1 := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			data[i] += flat[i*m+j]
+		}
+		data1[i] = flat[i*m]
+	}
+	resp := &inference.ModelInferResponse{ModelName: req.ModelName, ModelVersion: req.ModelVersion}
+	for _, d := range [][]float64{data, data1} {
+		out := &inference.ModelInferResponse_InferOutputTensor{Name: "predict", Datatype: "FP64", Shape: []int64{int64(n), 1}}
+		if s.raw {
+			b := make([]byte, 8*len(d))
+			for i, v := range d {
+				binary.LittleEndian.PutUint64(b[i*8:], math.Float64bits(v))
+			}
+			resp.RawOutputContents = append(resp.RawOutputContents, b)
+		} else {
+			out.Contents = &inference.InferTensorContents{Fp64Contents: d}
+		}
+		resp.Outputs = append(resp.Outputs, out)
+	}
+	return resp, nil
+}
+
+// newTestMLServerGRPC returns the address of a testMLServerGRPC.
+func newTestMLServerGRPC(t *testing.T, requests *int32, raw bool) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen err=%v", err)
+	}
+	sv := grpc.NewServer()
+	inference.RegisterGRPCInferenceServiceServer(sv, &testMLServerGRPC{t: t, requests: requests, raw: raw})
+	go sv.Serve(lis)
+	t.Cleanup(sv.Stop)
+	return lis.Addr().String()
+}
+
+func TestMLServerGRPCPCPredictor_PredictBatch(t *testing.T) {
+	var requests int32
+	addr := newTestMLServerGRPC(t, &requests, false)
+
+	p, err := NewMLServerGRPCPCPredictorFromURL("grpc://" + addr + "/v2/models/model1/versions/v0.1.0")
+	if err != nil {
+		t.Fatalf("NewMLServerGRPCPCPredictorFromURL() error = %v", err)
+	}
+	defer p.Close()
+	status := newNodeStatus(10, 20)
+	status.SetFloat(NodeStatusStaticPressureDiff, 0)
+	status.SetInt(NodeStatusLogicalProcessors, 4)
+
+	// cpuUsage=10+requestCPU/4000, ambientTemp=20, staticPressureDiff=0
+	rr := make([]ResourceRequest, 21)
+	want := make([]float64, 21)
+	for i := range rr {
+		rr[i] = ResourceRequest{CPUMilli: 1000 * i}
+		want[i] = 30 + 0.25*float64(i)
+	}
+	got, err := p.PredictBatch(context.Background(), rr, status)
+	if err != nil {
+		t.Fatalf("MLServerGRPCPCPredictor.PredictBatch() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MLServerGRPCPCPredictor.PredictBatch() = %v, want %v", got, want)
+	}
+	if requests != 1 {
+		t.Errorf("MLServerGRPCPCPredictor.PredictBatch() sent %d requests, want 1", requests)
+	}
+
+	got1, err := p.Predict(context.Background(), 1000, status)
+	if err != nil || got1 != 30.25 {
+		t.Errorf("MLServerGRPCPCPredictor.Predict() = %v, %v, want 30.25", got1, err)
+	}
+}
+
+func TestMLServerGRPCPCPredictor_PredictBatch_features(t *testing.T) {
+	status := newNodeStatus(10, 20)
+	status.SetInt(NodeStatusLogicalProcessors, 4)
+	rr := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 1000}, {CPUMilli: 2000}}
+
+	tests := []struct {
+		name        string
+		raw         bool
+		datatype    string
+		features    []NodeStatusKey
+		outputIndex int
+		want        []float64
+		wantErr     bool
+	}{
+		{"FP64", false, "FP64", []NodeStatusKey{NodeStatusAmbientTemp, NodeStatusCPUUsage}, 0, []float64{30, 30.25, 30.5}, false},
+		{"raw", true, "FP32", []NodeStatusKey{NodeStatusAmbientTemp, NodeStatusCPUUsage}, 0, []float64{30, 30.25, 30.5}, false},
+		{"raw_outputIndex", true, "FP64", []NodeStatusKey{NodeStatusCPUUsage, NodeStatusAmbientTemp}, 1, []float64{10, 10.25, 10.5}, false},
+		{"outputIndex_out_of_range", false, "FP32", []NodeStatusKey{NodeStatusCPUUsage}, 2, nil, true},
+		{"unsupported_datatype", false, "FP16", []NodeStatusKey{NodeStatusCPUUsage}, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			addr := newTestMLServerGRPC(t, &requests, tt.raw)
+			p := &MLServerGRPCPCPredictor{
+				Target:  addr,
+				Model:   "model1",
+				Version: "v0.1.0",
+				V2TensorSpec: V2TensorSpec{
+					Features:    tt.features,
+					Datatype:    tt.datatype,
+					OutputIndex: tt.outputIndex,
+				},
+			}
+			defer p.Close()
+			got, err := p.PredictBatch(context.Background(), rr, status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MLServerGRPCPCPredictor.PredictBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MLServerGRPCPCPredictor.PredictBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
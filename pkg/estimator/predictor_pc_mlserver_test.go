@@ -1,8 +1,12 @@
 package estimator
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync/atomic"
 	"testing"
 )
 
@@ -164,3 +168,177 @@ func TestNewMLServerPCPredictorFromURL(t *testing.T) {
 		})
 	}
 }
+
+// newTestMLServer returns a MLServer that predicts the sum of each row in outputs[0]
+// and the first column of each row in outputs[1], and counts requests.
+func newTestMLServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		var req mlServerPCPredictorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode err=%v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		in := req.Inputs[0]
+		if len(in.Shape) != 2 || in.Shape[0] != len(in.Data) || in.Shape[1] != len(in.Data[0]) {
+			t.Errorf("invalid shape=%v len(data)=%d", in.Shape, len(in.Data))
+		}
+		data := make([]float64, len(in.Data))
+		data1 := make([]float64, len(in.Data))
+		for i, row := range in.Data {
+			for _, v := range row {
+				data[i] += v
+			}
+			data1[i] = row[0]
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"outputs": []map[string]any{
+				{"name": "predict", "shape": []int{len(data), 1}, "datatype": "FP64", "data": data},
+				{"name": in.Name, "shape": []int{len(data1), 1}, "datatype": in.Datatype, "data": data1},
+			},
+		})
+	}))
+}
+
+func TestMLServerPCPredictor_PredictBatch(t *testing.T) {
+	var requests int32
+	sv := newTestMLServer(t, &requests)
+	defer sv.Close()
+
+	p, err := NewMLServerPCPredictorFromURL(sv.URL + "/v2/models/model1/versions/v0.1.0/infer")
+	if err != nil {
+		t.Fatalf("NewMLServerPCPredictorFromURL() error = %v", err)
+	}
+	status := newNodeStatus(10, 20)
+	status.SetFloat(NodeStatusStaticPressureDiff, 0)
+	status.SetInt(NodeStatusLogicalProcessors, 4)
+
+	// cpuUsage=10+requestCPU/4000, ambientTemp=20, staticPressureDiff=0
+	rr := make([]ResourceRequest, 21)
+	want := make([]float64, 21)
+	for i := range rr {
+		rr[i] = ResourceRequest{CPUMilli: 1000 * i}
+		want[i] = 30 + 0.25*float64(i)
+	}
+	got, err := p.PredictBatch(context.Background(), rr, status)
+	if err != nil {
+		t.Fatalf("MLServerPCPredictor.PredictBatch() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MLServerPCPredictor.PredictBatch() = %v, want %v", got, want)
+	}
+	if requests != 1 {
+		t.Errorf("MLServerPCPredictor.PredictBatch() sent %d requests, want 1", requests)
+	}
+
+	got1, err := p.Predict(context.Background(), 1000, status)
+	if err != nil || got1 != 30.25 {
+		t.Errorf("MLServerPCPredictor.Predict() = %v, %v, want 30.25", got1, err)
+	}
+}
+
+func TestMLServerPCPredictor_PredictBatch_features(t *testing.T) {
+	var requests int32
+	sv := newTestMLServer(t, &requests)
+	defer sv.Close()
+
+	status := newNodeStatus(10, 20)
+	status.SetInt(NodeStatusLogicalProcessors, 4)
+	rr := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 1000}, {CPUMilli: 2000}}
+
+	tests := []struct {
+		name        string
+		features    []NodeStatusKey
+		outputIndex int
+		want        []float64
+		wantErr     bool
+	}{
+		// outputs[0] is the sum of each row
+		{"cpuUsage_only", []NodeStatusKey{NodeStatusCPUUsage}, 0, []float64{10, 10.25, 10.5}, false},
+		{"reordered", []NodeStatusKey{NodeStatusAmbientTemp, NodeStatusCPUUsage}, 0, []float64{30, 30.25, 30.5}, false},
+		// outputs[1] is the first column of each row
+		{"outputIndex", []NodeStatusKey{NodeStatusAmbientTemp, NodeStatusCPUUsage}, 1, []float64{20, 20, 20}, false},
+		{"outputIndex_out_of_range", []NodeStatusKey{NodeStatusCPUUsage}, 2, nil, true},
+		// requests are ignored without cpuUsage
+		{"no_cpuUsage", []NodeStatusKey{NodeStatusAmbientTemp}, 0, []float64{20, 20, 20}, false},
+		{"missing_key", []NodeStatusKey{NodeStatusCPUUsage, NodeStatusStaticPressureDiff}, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewMLServerPCPredictorFromURL(sv.URL + "/v2/models/model1/versions/v0.1.0/infer")
+			if err != nil {
+				t.Fatalf("NewMLServerPCPredictorFromURL() error = %v", err)
+			}
+			p.Features = tt.features
+			p.InputName = "input-0"
+			p.Datatype = "FP64"
+			p.OutputIndex = tt.outputIndex
+			got, err := p.PredictBatch(context.Background(), rr, status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MLServerPCPredictor.PredictBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MLServerPCPredictor.PredictBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestV2TensorSpec_buildInputs(t *testing.T) {
+	status := newNodeStatus(10, 20)
+	status.SetFloat(NodeStatusStaticPressureDiff, 0)
+	status.SetInt(NodeStatusLogicalProcessors, 4)
+
+	tests := []struct {
+		name     string
+		features []NodeStatusKey
+		requests []ResourceRequest
+		want     [][]float64
+	}{
+		// the requested CPU is added to cpuUsage as a fraction of the logical processors
+		{"default", nil, []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 1000}},
+			[][]float64{{10, 20, 0}, {10.25, 20, 0}}},
+		{"features", []NodeStatusKey{NodeStatusAmbientTemp, NodeStatusCPUUsage, NodeStatusStaticPressureDiff}, []ResourceRequest{{CPUMilli: 1000}},
+			[][]float64{{20, 10.25, 0}}},
+		{"request_features", []NodeStatusKey{NodeStatusCPUUsage, FeatureRequestMemoryBytes, FeatureRequestExtendedResourcePrefix + "nvidia.com/gpu"},
+			[]ResourceRequest{{CPUMilli: 1000, MemoryBytes: 1 << 30, ExtendedResources: map[string]int64{"nvidia.com/gpu": 2}}, {MemoryBytes: 1 << 20}},
+			[][]float64{{10.25, 1 << 30, 2}, {10, 1 << 20, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &V2TensorSpec{Features: tt.features}
+			got, err := s.buildInputs(tt.requests, status)
+			if err != nil {
+				t.Fatalf("V2TensorSpec.buildInputs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("V2TensorSpec.buildInputs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMLServerPCPredictor_newBatchRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *MLServerPCPredictor
+		want string
+	}{
+		{"default", &MLServerPCPredictor{},
+			`{"inputs":[{"name":"predict-prob","shape":[2,2],"datatype":"FP32","data":[[10,22],[20,22]]}]}`},
+		{"custom", &MLServerPCPredictor{V2TensorSpec: V2TensorSpec{InputName: "input-0", Datatype: "FP64"}},
+			`{"inputs":[{"name":"input-0","shape":[2,2],"datatype":"FP64","data":[[10,22],[20,22]]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := json.Marshal(tt.p.newBatchRequest([][]float64{{10, 22}, {20, 22}}))
+			if err != nil {
+				t.Errorf("unable to encode err=%v", err)
+			}
+			if string(p) != tt.want {
+				t.Errorf("MLServerPCPredictor.newBatchRequest() = %s, want %s", p, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package estimator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// PolynomialPCPredictor evaluates a polynomial over NodeStatus values in-process,
+// e.g. linear regression models exported as coefficients.
+//
+//	watt = Intercept + sum(Terms[i].Coefficient * prod(feature^power))
+//
+// The requested CPU is added to NodeStatusCPUUsage in the same way as MLServerPCPredictor does,
+// and the request features (e.g. FeatureRequestMemoryBytes) are filled with the requested resources.
+type PolynomialPCPredictor struct {
+	Intercept float64
+	Terms     []PolynomialTerm
+
+	// spec builds the values of the features used in Terms
+	spec V2TensorSpec
+	// termPowers[i][j] is the power of spec.Features[j] in Terms[i]
+	termPowers [][]int
+}
+
+// PolynomialTerm is Coefficient * prod(key^Powers[key]).
+type PolynomialTerm struct {
+	Coefficient float64
+	// Powers maps NodeStatusKeys or request features to their powers, e.g. {NodeStatusCPUUsage: 2, NodeStatusAmbientTemp: 1}
+	Powers map[NodeStatusKey]int
+}
+
+var _ PowerConsumptionPredictor = (*PolynomialPCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*PolynomialPCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*PolynomialPCPredictor)(nil)
+var _ NodeStatusConsumer = (*PolynomialPCPredictor)(nil)
+
+// NewPolynomialPCPredictor validates the given terms, powers must not be negative.
+func NewPolynomialPCPredictor(intercept float64, terms []PolynomialTerm) (*PolynomialPCPredictor, error) {
+	keys := map[NodeStatusKey]struct{}{}
+	for i, t := range terms {
+		for k, pow := range t.Powers {
+			if pow < 0 {
+				return nil, fmt.Errorf("terms[%d] has a negative power %s^%d (%w)", i, k, pow, ErrPCPredictor)
+			}
+			keys[k] = struct{}{}
+		}
+	}
+	features := make([]NodeStatusKey, 0, len(keys))
+	for k := range keys {
+		features = append(features, k)
+	}
+	sort.Slice(features, func(i, j int) bool { return features[i] < features[j] })
+
+	termPowers := make([][]int, len(terms))
+	for i, t := range terms {
+		termPowers[i] = make([]int, len(features))
+		for j, k := range features {
+			termPowers[i][j] = t.Powers[k]
+		}
+	}
+
+	return &PolynomialPCPredictor{
+		Intercept:  intercept,
+		Terms:      terms,
+		spec:       V2TensorSpec{Features: features},
+		termPowers: termPowers,
+	}, nil
+}
+
+func (p *PolynomialPCPredictor) ConsumedNodeStatusKeys() []NodeStatusKey {
+	if len(p.spec.Features) == 0 {
+		return nil
+	}
+	return p.spec.ConsumedNodeStatusKeys()
+}
+
+func (p *PolynomialPCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	return p.PredictResources(ctx, ResourceRequest{CPUMilli: requestCPUMilli}, status)
+}
+
+func (p *PolynomialPCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	watts, err := p.PredictBatch(ctx, []ResourceRequest{request}, status)
+	if err != nil {
+		return math.MaxFloat64, err
+	}
+	return watts[0], nil
+}
+
+// PredictBatch evaluates the polynomial for each request,
+// resources other than CPU are used only if the request features are in Terms.
+func (p *PolynomialPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	if len(requests) == 0 {
+		return []float64{}, nil
+	}
+
+	var inputs [][]float64
+	if len(p.spec.Features) == 0 {
+		// constant
+		inputs = make([][]float64, len(requests))
+	} else {
+		inputs, err = p.spec.buildInputs(requests, status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	watts = make([]float64, len(requests))
+	for i, x := range inputs {
+		watts[i] = p.evaluate(x)
+	}
+	return watts, nil
+}
+
+// evaluate returns the value at x, x[j] is the value of spec.Features[j].
+func (p *PolynomialPCPredictor) evaluate(x []float64) float64 {
+	v := p.Intercept
+	for i, t := range p.Terms {
+		term := t.Coefficient
+		for j, pow := range p.termPowers[i] {
+			if pow != 0 {
+				term *= math.Pow(x[j], float64(pow))
+			}
+		}
+		v += term
+	}
+	return v
+}
@@ -0,0 +1,92 @@
+package estimator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPolynomialPCPredictor_PredictBatch(t *testing.T) {
+	status := newNodeStatus(10, 20)
+	status.SetInt(NodeStatusLogicalProcessors, 4)
+	// cpuUsage=10+requestCPU/4000, ambientTemp=20
+	rr := []ResourceRequest{
+		{CPUMilli: 0},
+		{CPUMilli: 1000, MemoryBytes: 1 << 30},
+		{CPUMilli: 2000, MemoryBytes: 2 << 30, ExtendedResources: map[string]int64{"nvidia.com/gpu": 1}},
+	}
+
+	tests := []struct {
+		name      string
+		intercept float64
+		terms     []PolynomialTerm
+		status    *NodeStatus
+		want      []float64
+		wantErr   bool
+	}{
+		{"constant", 50, nil, NewNodeStatus(), []float64{50, 50, 50}, false},
+		{"linear", 50, []PolynomialTerm{
+			{Coefficient: 2, Powers: map[NodeStatusKey]int{NodeStatusCPUUsage: 1}},
+			{Coefficient: 0.5, Powers: map[NodeStatusKey]int{NodeStatusAmbientTemp: 1}},
+		}, status, []float64{80, 80.5, 81}, false},
+		{"polynomial", 0, []PolynomialTerm{
+			{Coefficient: 1, Powers: map[NodeStatusKey]int{NodeStatusCPUUsage: 2}},
+			{Coefficient: 0.25, Powers: map[NodeStatusKey]int{NodeStatusCPUUsage: 1, NodeStatusAmbientTemp: 2}},
+		}, status, []float64{100 + 1000, 105.0625 + 1025, 110.25 + 1050}, false},
+		{"request_features", 50, []PolynomialTerm{
+			{Coefficient: 2, Powers: map[NodeStatusKey]int{NodeStatusCPUUsage: 1}},
+			{Coefficient: 1.0 / (1 << 30), Powers: map[NodeStatusKey]int{FeatureRequestMemoryBytes: 1}},
+			{Coefficient: 100, Powers: map[NodeStatusKey]int{FeatureRequestExtendedResourcePrefix + "nvidia.com/gpu": 1}},
+		}, status, []float64{70, 71.5, 173}, false},
+		{"zero_power", 1, []PolynomialTerm{
+			{Coefficient: 3, Powers: map[NodeStatusKey]int{NodeStatusAmbientTemp: 0}},
+		}, status, []float64{4, 4, 4}, false},
+		{"missing_key", 0, []PolynomialTerm{
+			{Coefficient: 1, Powers: map[NodeStatusKey]int{NodeStatusStaticPressureDiff: 1}},
+		}, status, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPolynomialPCPredictor(tt.intercept, tt.terms)
+			if err != nil {
+				t.Fatalf("NewPolynomialPCPredictor() error = %v", err)
+			}
+			got, err := p.PredictBatch(context.Background(), rr, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PolynomialPCPredictor.PredictBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PolynomialPCPredictor.PredictBatch() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			got1, err := p.PredictResources(context.Background(), rr[1], tt.status)
+			if err != nil || got1 != tt.want[1] {
+				t.Errorf("PolynomialPCPredictor.PredictResources() = %v, %v, want %v", got1, err, tt.want[1])
+			}
+		})
+	}
+}
+
+func TestPolynomialPCPredictor_ConsumedNodeStatusKeys(t *testing.T) {
+	p, err := NewPolynomialPCPredictor(0, []PolynomialTerm{
+		{Coefficient: 1, Powers: map[NodeStatusKey]int{NodeStatusCPUUsage: 1, FeatureRequestMemoryBytes: 1}},
+		{Coefficient: 1, Powers: map[NodeStatusKey]int{FeatureRequestExtendedResourcePrefix + "nvidia.com/gpu": 1}},
+	})
+	if err != nil {
+		t.Fatalf("NewPolynomialPCPredictor() error = %v", err)
+	}
+	// the request features are not NodeStatus keys
+	want := []NodeStatusKey{NodeStatusCPUUsage, NodeStatusLogicalProcessors}
+	if got := p.ConsumedNodeStatusKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PolynomialPCPredictor.ConsumedNodeStatusKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestNewPolynomialPCPredictor(t *testing.T) {
+	_, err := NewPolynomialPCPredictor(0, []PolynomialTerm{{Coefficient: 1, Powers: map[NodeStatusKey]int{NodeStatusCPUUsage: -1}}})
+	if err == nil {
+		t.Errorf("NewPolynomialPCPredictor() error = nil for a negative power")
+	}
+}
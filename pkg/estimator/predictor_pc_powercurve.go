@@ -0,0 +1,145 @@
+package estimator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PowerCurvePCPredictor predicts by linear interpolation of a power curve (e.g. SPECpower results),
+// so no ML servers are needed.
+//
+// The CPU usage is NodeStatusCPUUsage plus the requested CPU, clamped to the range of the curve.
+type PowerCurvePCPredictor struct {
+	// Points is the power curve sorted by CPUUsage.
+	Points []PowerCurvePoint
+	// AmbientTempCorrection is applied if not nil, NodeStatusAmbientTemp is required.
+	AmbientTempCorrection *AmbientTempCorrection
+}
+
+// PowerCurvePoint is a point of a power curve.
+type PowerCurvePoint struct {
+	// CPUUsage in the unit of NodeStatusCPUUsage
+	CPUUsage float64
+	Watts    float64
+}
+
+// AmbientTempCorrection adds (ambientTemp - ReferenceTemp) * WattsPerDegree to predictions.
+type AmbientTempCorrection struct {
+	// ReferenceTemp is the ambient temperature in Celsius at which the curve was measured.
+	ReferenceTemp float64
+	// WattsPerDegree is the increase of power consumption per degree Celsius.
+	WattsPerDegree float64
+}
+
+var _ PowerConsumptionPredictor = (*PowerCurvePCPredictor)(nil)
+var _ NodeStatusConsumer = (*PowerCurvePCPredictor)(nil)
+
+// NewPowerCurvePCPredictor sorts the given points and validates them.
+func NewPowerCurvePCPredictor(points []PowerCurvePoint, atc *AmbientTempCorrection) (*PowerCurvePCPredictor, error) {
+	if len(points) == 0 {
+		return nil, fmt.Errorf("power curve must have at least one point (%w)", ErrPCPredictor)
+	}
+	ps := make([]PowerCurvePoint, len(points))
+	copy(ps, points)
+	sort.SliceStable(ps, func(i, j int) bool { return ps[i].CPUUsage < ps[j].CPUUsage })
+	for i, p := range ps {
+		if math.IsNaN(p.CPUUsage) || math.IsNaN(p.Watts) || math.IsInf(p.CPUUsage, 0) || math.IsInf(p.Watts, 0) {
+			return nil, fmt.Errorf("power curve has an invalid point %+v (%w)", p, ErrPCPredictor)
+		}
+		if i > 0 && ps[i-1].CPUUsage == p.CPUUsage {
+			return nil, fmt.Errorf("power curve has duplicate points at cpuUsage=%v (%w)", p.CPUUsage, ErrPCPredictor)
+		}
+	}
+	return &PowerCurvePCPredictor{Points: ps, AmbientTempCorrection: atc}, nil
+}
+
+// ParsePowerCurve parses a power curve in CSV, each line is "{cpuUsage},{watts}".
+// Empty lines, lines starting with "#" and a non-numeric header line are ignored.
+// cpuUsage is in percent like SPECpower load levels, and converted to the unit of NodeStatusCPUUsage.
+//
+// e.g.
+//
+//	cpuUsage,watts
+//	0,50.5
+//	10,70
+//	100,200
+func ParsePowerCurve(s string) ([]PowerCurvePoint, error) {
+	var points []PowerCurvePoint
+	sc := bufio.NewScanner(strings.NewReader(s))
+	for i := 1; sc.Scan(); i++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cols := strings.Split(line, ",")
+		if len(cols) != 2 {
+			return nil, fmt.Errorf("line %d: want 2 columns but got %d (%w)", i, len(cols), ErrPCPredictor)
+		}
+		u, errU := strconv.ParseFloat(strings.TrimSpace(cols[0]), 64)
+		w, errW := strconv.ParseFloat(strings.TrimSpace(cols[1]), 64)
+		if errU != nil || errW != nil {
+			if len(points) == 0 && errU != nil && errW != nil { // header
+				continue
+			}
+			return nil, fmt.Errorf("line %d: %q is not a number pair (%w)", i, line, ErrPCPredictor)
+		}
+		points = append(points, PowerCurvePoint{CPUUsage: u / 100, Watts: w})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("could not read power curve (%w): %v", ErrPCPredictor, err)
+	}
+	return points, nil
+}
+
+func (p *PowerCurvePCPredictor) ConsumedNodeStatusKeys() []NodeStatusKey {
+	keys := []NodeStatusKey{NodeStatusCPUUsage, NodeStatusLogicalProcessors}
+	if p.AmbientTempCorrection != nil {
+		keys = append(keys, NodeStatusAmbientTemp)
+	}
+	return keys
+}
+
+func (p *PowerCurvePCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	currentCPUUsage, err := status.GetFloat(NodeStatusCPUUsage)
+	if err != nil {
+		return math.MaxFloat64, fmt.Errorf("could not get current CPU usage (%w): %v", ErrPCPredictor, err)
+	}
+
+	logicalProcessors, err := status.GetInt(NodeStatusLogicalProcessors)
+	if err != nil {
+		return math.MaxFloat64, fmt.Errorf("could not get logical processors (%w): %v", ErrPCPredictor, err)
+	}
+
+	cpuUsage := currentCPUUsage + float64(requestCPUMilli)/float64(logicalProcessors*1000)
+	watt = p.interpolate(cpuUsage)
+
+	if p.AmbientTempCorrection != nil {
+		ambientTemp, err := status.GetFloat(NodeStatusAmbientTemp)
+		if err != nil {
+			return math.MaxFloat64, fmt.Errorf("could not get ambient temp (%w): %v", ErrPCPredictor, err)
+		}
+		watt += (ambientTemp - p.AmbientTempCorrection.ReferenceTemp) * p.AmbientTempCorrection.WattsPerDegree
+	}
+
+	return watt, nil
+}
+
+// interpolate returns the watts at the given CPU usage, the usage is clamped to the range of the curve.
+func (p *PowerCurvePCPredictor) interpolate(cpuUsage float64) float64 {
+	ps := p.Points
+	if cpuUsage <= ps[0].CPUUsage {
+		return ps[0].Watts
+	}
+	if cpuUsage >= ps[len(ps)-1].CPUUsage {
+		return ps[len(ps)-1].Watts
+	}
+	// ps[i-1].CPUUsage < cpuUsage <= ps[i].CPUUsage
+	i := sort.Search(len(ps), func(i int) bool { return ps[i].CPUUsage >= cpuUsage })
+	p0, p1 := ps[i-1], ps[i]
+	return p0.Watts + (p1.Watts-p0.Watts)*(cpuUsage-p0.CPUUsage)/(p1.CPUUsage-p0.CPUUsage)
+}
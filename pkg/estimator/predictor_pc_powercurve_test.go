@@ -0,0 +1,106 @@
+package estimator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParsePowerCurve(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []PowerCurvePoint
+		wantErr bool
+	}{
+		{"header", "cpuUsage,watts\n0,50.5\n10, 70\n\n# comment\n100,200\n",
+			[]PowerCurvePoint{{0, 50.5}, {0.1, 70}, {1, 200}}, false},
+		{"no_header", "0,50\n100,200", []PowerCurvePoint{{0, 50}, {1, 200}}, false},
+		{"columns", "0,50,1\n", nil, true},
+		{"not_a_number", "0,50\n10,foo\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePowerCurve(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePowerCurve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePowerCurve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPowerCurvePCPredictor(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  []PowerCurvePoint
+		want    []PowerCurvePoint
+		wantErr bool
+	}{
+		{"sort", []PowerCurvePoint{{1, 200}, {0, 50}, {0.5, 120}}, []PowerCurvePoint{{0, 50}, {0.5, 120}, {1, 200}}, false},
+		{"empty", nil, nil, true},
+		{"duplicate", []PowerCurvePoint{{0, 50}, {0, 60}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPowerCurvePCPredictor(tt.points, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPowerCurvePCPredictor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got.Points, tt.want) {
+				t.Errorf("NewPowerCurvePCPredictor() = %v, want %v", got.Points, tt.want)
+			}
+		})
+	}
+}
+
+func TestPowerCurvePCPredictor_Predict(t *testing.T) {
+	curve := []PowerCurvePoint{{0, 50}, {0.1, 70}, {0.5, 110}, {1, 200}}
+	newStatus := func(cpuUsage, ambientTemp float64, ok bool) *NodeStatus {
+		s := NewNodeStatus()
+		s.SetFloat(NodeStatusCPUUsage, cpuUsage)
+		s.SetInt(NodeStatusLogicalProcessors, 4)
+		if ok {
+			s.SetFloat(NodeStatusAmbientTemp, ambientTemp)
+		}
+		return s
+	}
+	tests := []struct {
+		name            string
+		atc             *AmbientTempCorrection
+		requestCPUMilli int
+		status          *NodeStatus
+		want            float64
+		wantErr         bool
+	}{
+		{"point", nil, 0, newStatus(0.1, 0, false), 70, false},
+		// 0.1 + 400m/4000m = 0.2
+		{"interpolate", nil, 400, newStatus(0.1, 0, false), 80, false},
+		{"interpolate2", nil, 1000, newStatus(0.5, 0, false), 155, false},
+		{"clamp_max", nil, 4000, newStatus(0.5, 0, false), 200, false},
+		{"ambient", &AmbientTempCorrection{ReferenceTemp: 25, WattsPerDegree: 2}, 0, newStatus(0.1, 30, true), 80, false},
+		{"ambient_lower", &AmbientTempCorrection{ReferenceTemp: 25, WattsPerDegree: 2}, 0, newStatus(0.1, 20, true), 60, false},
+		{"ambient_missing", &AmbientTempCorrection{ReferenceTemp: 25, WattsPerDegree: 2}, 0, newStatus(0.1, 0, false), 0, true},
+		{"cpuUsage_missing", nil, 0, NewNodeStatus(), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPowerCurvePCPredictor(curve, tt.atc)
+			if err != nil {
+				t.Fatalf("NewPowerCurvePCPredictor() error = %v", err)
+			}
+			got, err := p.Predict(context.Background(), tt.requestCPUMilli, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PowerCurvePCPredictor.Predict() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("PowerCurvePCPredictor.Predict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,13 +2,14 @@ package estimator
 
 import (
 	"context"
+	"reflect"
 	"testing"
 )
 
 func newNodeStatus(cpuUsage, ambientTemp float64) *NodeStatus {
 	v := NewNodeStatus()
-	NodeStatusSetCPUUsage(v, cpuUsage)
-	NodeStatusSetAmbientTemp(v, ambientTemp)
+	v.SetFloat(NodeStatusCPUUsage, cpuUsage)
+	v.SetFloat(NodeStatusAmbientTemp, ambientTemp)
 	return v
 }
 
@@ -49,3 +50,134 @@ func TestFakePCPredictor_Predict(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceRequest_Mul(t *testing.T) {
+	tests := []struct {
+		name string
+		r    ResourceRequest
+		n    int
+		want ResourceRequest
+	}{
+		{"cpu", ResourceRequest{CPUMilli: 500}, 3, ResourceRequest{CPUMilli: 1500}},
+		{"all", ResourceRequest{CPUMilli: 500, MemoryBytes: 1 << 30, ExtendedResources: map[string]int64{"nvidia.com/gpu": 1}}, 2,
+			ResourceRequest{CPUMilli: 1000, MemoryBytes: 2 << 30, ExtendedResources: map[string]int64{"nvidia.com/gpu": 2}}},
+		{"0", ResourceRequest{CPUMilli: 500, MemoryBytes: 1 << 30, ExtendedResources: map[string]int64{"nvidia.com/gpu": 1}}, 0,
+			ResourceRequest{ExtendedResources: map[string]int64{"nvidia.com/gpu": 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Mul(tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceRequest.Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceRequest_Add(t *testing.T) {
+	tests := []struct {
+		name string
+		r    ResourceRequest
+		o    ResourceRequest
+		want ResourceRequest
+	}{
+		{"cpu", ResourceRequest{CPUMilli: 500}, ResourceRequest{CPUMilli: 1000}, ResourceRequest{CPUMilli: 1500}},
+		{"all", ResourceRequest{CPUMilli: 500, MemoryBytes: 1 << 30}, ResourceRequest{CPUMilli: 1000, ExtendedResources: map[string]int64{"nvidia.com/gpu": 1}},
+			ResourceRequest{CPUMilli: 1500, MemoryBytes: 1 << 30, ExtendedResources: map[string]int64{"nvidia.com/gpu": 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Add(tt.o); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceRequest.Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testPCPredictorV1 implements PowerConsumptionPredictor only.
+type testPCPredictorV1 struct{}
+
+func (testPCPredictorV1) Predict(_ context.Context, requestCPUMilli int, _ *NodeStatus) (float64, error) {
+	return float64(requestCPUMilli) / 100, nil
+}
+
+func TestToPCPredictorV2(t *testing.T) {
+	// 100mCPU/W + 1GiB/W
+	predictResources := func(_ context.Context, r ResourceRequest, _ *NodeStatus) (float64, error) {
+		return float64(r.CPUMilli)/100 + float64(r.MemoryBytes)/(1<<30), nil
+	}
+	request := ResourceRequest{CPUMilli: 500, MemoryBytes: 2 << 30}
+	tests := []struct {
+		name     string
+		p        PowerConsumptionPredictor
+		wantWatt float64
+		wantErr  bool
+	}{
+		{"v1", testPCPredictorV1{}, 5, false},
+		{"fake_v1", &FakePCPredictor{PredictFunc: testPCPredictorV1{}.Predict}, 5, false},
+		{"fake_v2", &FakePCPredictor{PredictResourcesFunc: predictResources}, 7, false},
+		{"nil", nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotWatt, err := ToPCPredictorV2(tt.p).PredictResources(context.Background(), request, NewNodeStatus())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PredictResources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotWatt != tt.wantWatt {
+				t.Errorf("PredictResources() = %v, want %v", gotWatt, tt.wantWatt)
+			}
+		})
+	}
+}
+
+// testBatchPCPredictor implements BatchPowerConsumptionPredictor and counts calls.
+type testBatchPCPredictor struct {
+	testPCPredictorV1
+	calls int
+	n     int // returns n values if > 0
+}
+
+func (p *testBatchPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) ([]float64, error) {
+	p.calls++
+	n := len(requests)
+	if p.n > 0 {
+		n = p.n
+	}
+	watts := make([]float64, n)
+	for i := range watts {
+		watts[i], _ = p.Predict(ctx, requests[i%len(requests)].CPUMilli, status)
+	}
+	return watts, nil
+}
+
+func TestPredictBatch(t *testing.T) {
+	requests := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 500}, {CPUMilli: 1000}}
+	tests := []struct {
+		name      string
+		p         PowerConsumptionPredictor
+		want      []float64
+		wantCalls int
+		wantErr   bool
+	}{
+		{"batch", &testBatchPCPredictor{}, []float64{0, 5, 10}, 1, false},
+		{"batch_wrong_len", &testBatchPCPredictor{n: 2}, nil, 1, true},
+		{"fallback", testPCPredictorV1{}, []float64{0, 5, 10}, 0, false},
+		{"fallback_err", &FakePCPredictor{}, nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PredictBatch(context.Background(), tt.p, requests, NewNodeStatus())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PredictBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PredictBatch() = %v, want %v", got, tt.want)
+			}
+			if bp, ok := tt.p.(*testBatchPCPredictor); ok && bp.calls != tt.wantCalls {
+				t.Errorf("PredictBatch() calls = %v, want %v", bp.calls, tt.wantCalls)
+			}
+		})
+	}
+}
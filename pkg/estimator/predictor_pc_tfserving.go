@@ -0,0 +1,223 @@
+package estimator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+
+	http2curl "moul.io/http2curl/v2"
+)
+
+// TFServingPCPredictor predicts with the TensorFlow Serving REST predict API.
+//
+// Features, InputName and OutputIndex in V2TensorSpec are used, Datatype is ignored.
+// If InputName is set, each instance is sent as an object {InputName: row}, otherwise as a row.
+type TFServingPCPredictor struct {
+	// Server specifies server address
+	// e.g. "http://localhost:8501"
+	Server string
+	// Model specifies model name
+	// e.g. "model1"
+	Model string
+	// Version specifies version for the model, the latest version is used if empty
+	// e.g. "1"
+	Version string
+	// SignatureName specifies the signature, "serving_default" is used if empty
+	SignatureName string
+	// HTTPClient sends the requests, http.DefaultClient is used if nil.
+	HTTPClient *HTTPClient
+
+	V2TensorSpec
+}
+
+var _ PowerConsumptionPredictor = (*TFServingPCPredictor)(nil)
+var _ PowerConsumptionPredictorV2 = (*TFServingPCPredictor)(nil)
+var _ BatchPowerConsumptionPredictor = (*TFServingPCPredictor)(nil)
+var _ NodeStatusConsumer = (*TFServingPCPredictor)(nil)
+
+// NewTFServingPCPredictorFromURL parses the given endpoint URL.
+//
+// Format: {Server}/v1/models/{Model}[/versions/{Version}][:predict]
+// Example: http://hogehoge:8501/v1/models/model1/versions/1:predict -> &{Server: "http://hogehoge:8501", Model: "model1", Version: "1"}
+func NewTFServingPCPredictorFromURL(endpoint string) (*TFServingPCPredictor, error) {
+	parsedURL, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse TFServing endpoint URL %w: %v", ErrPCPredictor, err)
+	}
+	path := strings.TrimSuffix(strings.TrimSuffix(parsedURL.Path, "/"), ":predict")
+	ss := strings.Split(path, "/") // "/v1/models/model1/versions/1" -> ["", "v1", "models", "model1", "versions", "1"]
+	if len(ss) < 4 || ss[1] != "v1" || ss[2] != "models" || ss[3] == "" {
+		return nil, fmt.Errorf("could not parse TFServing endpoint URL %w: url path must be in {Server}/v1/models/{Model}[/versions/{Version}][:predict] format", ErrPCPredictor)
+	}
+	p := &TFServingPCPredictor{
+		Server: parsedURL.Scheme + "://" + parsedURL.Host,
+		Model:  ss[3],
+	}
+	if len(ss) >= 6 && ss[4] == "versions" {
+		p.Version = ss[5]
+	}
+	return p, nil
+}
+
+func (p *TFServingPCPredictor) Predict(ctx context.Context, requestCPUMilli int, status *NodeStatus) (watt float64, err error) {
+	return p.PredictResources(ctx, ResourceRequest{CPUMilli: requestCPUMilli}, status)
+}
+
+func (p *TFServingPCPredictor) PredictResources(ctx context.Context, request ResourceRequest, status *NodeStatus) (watt float64, err error) {
+	watts, err := p.PredictBatch(ctx, []ResourceRequest{request}, status)
+	if err != nil {
+		return math.MaxFloat64, err
+	}
+	return watts[0], nil
+}
+
+// PredictBatch sends all the requests as instances in one request,
+// resources other than CPU are used only if the request features are in Features.
+func (p *TFServingPCPredictor) PredictBatch(ctx context.Context, requests []ResourceRequest, status *NodeStatus) (watts []float64, err error) {
+	if len(requests) == 0 {
+		return []float64{}, nil
+	}
+
+	inputs, err := p.buildInputs(requests, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.POSTPredictRequest(ctx, inputs)
+}
+
+// getURLPredict returns the API endpoint.
+// e.g. "http://localhost:8501/v1/models/model1/versions/1:predict"
+func (p *TFServingPCPredictor) getURLPredict() (string, error) {
+	elem := []string{"v1", "models", p.Model}
+	if p.Version != "" {
+		elem = append(elem, "versions", p.Version)
+	}
+	u, err := url.JoinPath(p.Server, elem...)
+	if err != nil {
+		return "", err
+	}
+	return u + ":predict", nil
+}
+
+// POSTPredictRequest sends inputs[N][len(Features)] as N instances in one request
+// and returns N predictions.
+func (p *TFServingPCPredictor) POSTPredictRequest(ctx context.Context, inputs [][]float64) ([]float64, error) {
+
+	url, err := p.getURLPredict()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get endpoint URL: %w", err)
+	}
+
+	body, err := json.Marshal(p.newRequest(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the request body=%+v err=%w", body, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create HTTP request: %w", err)
+	}
+
+	curl, err := http2curl.GetCurlCommand(req)
+	if err != nil {
+		lg.Err(err).Msgf("TFServingPCPredictor.Predict could not parse http.Request to curl command")
+	} else {
+		lg.Trace().Msgf("TFServingPCPredictor.Predict request=%v", curl.String())
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send HTTP request: %w", err)
+	}
+	defer DrainAndClose(resp.Body)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var predictResp tfServingPCPredictorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&predictResp); err != nil {
+			return nil, fmt.Errorf("could not decode resp: %w", err)
+		}
+		if len(predictResp.Predictions) != len(inputs) {
+			return nil, fmt.Errorf("invalid response len(inputs)=%d predictResp=%+v", len(inputs), predictResp)
+		}
+		watts := make([]float64, len(inputs))
+		for i, v := range predictResp.Predictions {
+			w, err := p.decodePrediction(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid response predictions[%d]=%s: %w", i, v, err)
+			}
+			watts[i] = w
+		}
+		return watts, nil
+	default:
+		return nil, fmt.Errorf("HTTP status=%v request=%v", resp.Status, curl.String())
+	}
+
+}
+
+// decodePrediction decodes a prediction in the row format,
+// it is a scalar or an array of which OutputIndex-th value is used.
+func (p *TFServingPCPredictor) decodePrediction(v json.RawMessage) (float64, error) {
+	var f float64
+	if err := json.Unmarshal(v, &f); err == nil {
+		return f, nil
+	}
+	var fs []float64
+	if err := json.Unmarshal(v, &fs); err != nil {
+		return 0.0, err
+	}
+	if p.OutputIndex < 0 || p.OutputIndex >= len(fs) {
+		return 0.0, fmt.Errorf("outputIndex=%d out of range", p.OutputIndex)
+	}
+	return fs[p.OutputIndex], nil
+}
+
+// tfServingPCPredictorRequest holds a request in the row format.
+//
+// e.g.
+//
+//	{
+//	  "instances": [ [ 10, 22, 0.2 ], [ 20, 22, 0.2 ] ]
+//	}
+//
+// or with InputName and SignatureName
+//
+//	{
+//	  "signature_name": "serving_default",
+//	  "instances": [ { "input_1": [ 10, 22, 0.2 ] }, { "input_1": [ 20, 22, 0.2 ] } ]
+//	}
+type tfServingPCPredictorRequest struct {
+	SignatureName string `json:"signature_name,omitempty"`
+	Instances     []any  `json:"instances"`
+}
+
+func (p *TFServingPCPredictor) newRequest(inputs [][]float64) *tfServingPCPredictorRequest {
+	req := &tfServingPCPredictorRequest{
+		SignatureName: p.SignatureName,
+		Instances:     make([]any, len(inputs)),
+	}
+	for i, row := range inputs {
+		if p.InputName == "" {
+			req.Instances[i] = row
+		} else {
+			req.Instances[i] = map[string][]float64{p.InputName: row}
+		}
+	}
+	return req
+}
+
+// tfServingPCPredictorResponse holds a response.
+//
+// e.g.
+//
+//	{
+//	  "predictions": [ [ 94.76267448501928 ], [ 96.0 ] ]
+//	}
+type tfServingPCPredictorResponse struct {
+	Predictions []json.RawMessage `json:"predictions"`
+}
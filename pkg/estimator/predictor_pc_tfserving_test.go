@@ -0,0 +1,171 @@
+package estimator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewTFServingPCPredictorFromURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     *TFServingPCPredictor
+		wantErr  bool
+	}{
+		{"predict", "http://localhost:8501/v1/models/model1:predict", &TFServingPCPredictor{
+			Server: "http://localhost:8501",
+			Model:  "model1",
+		}, false},
+		{"version", "http://localhost:8501/v1/models/model1/versions/2:predict", &TFServingPCPredictor{
+			Server:  "http://localhost:8501",
+			Model:   "model1",
+			Version: "2",
+		}, false},
+		{"no_predict", "https://10.0.0.1/v1/models/model2/versions/3/", &TFServingPCPredictor{
+			Server:  "https://10.0.0.1",
+			Model:   "model2",
+			Version: "3",
+		}, false},
+		{"no_model", "http://localhost:8501/v1/models/", nil, true},
+		{"mlserver", "http://localhost:8080/v2/models/model1/versions/v0.1.0/infer", nil, true},
+		{"no_scheme", "localhost:8501/v1/models/model1:predict", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTFServingPCPredictorFromURL(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTFServingPCPredictorFromURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTFServingPCPredictorFromURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTFServingPCPredictor_getURLPredict(t *testing.T) {
+	tests := []struct {
+		p    *TFServingPCPredictor
+		want string
+	}{
+		{&TFServingPCPredictor{Server: "http://localhost:8501", Model: "model1"}, "http://localhost:8501/v1/models/model1:predict"},
+		{&TFServingPCPredictor{Server: "http://localhost:8501", Model: "model1", Version: "2"}, "http://localhost:8501/v1/models/model1/versions/2:predict"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := tt.p.getURLPredict()
+			if err != nil {
+				t.Errorf("TFServingPCPredictor.getURLPredict() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TFServingPCPredictor.getURLPredict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTFServingPCPredictor_newRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *TFServingPCPredictor
+		want string
+	}{
+		{"row", &TFServingPCPredictor{},
+			`{"instances":[[10,22,0.2],[20,22,0.2]]}`},
+		{"named", &TFServingPCPredictor{SignatureName: "serving_default", V2TensorSpec: V2TensorSpec{InputName: "input_1"}},
+			`{"signature_name":"serving_default","instances":[{"input_1":[10,22,0.2]},{"input_1":[20,22,0.2]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := json.Marshal(tt.p.newRequest([][]float64{{10, 22, 0.2}, {20, 22, 0.2}}))
+			if err != nil {
+				t.Errorf("unable to encode err=%v", err)
+			}
+			if string(p) != tt.want {
+				t.Errorf("TFServingPCPredictor.newRequest() = %s, want %s", p, tt.want)
+			}
+		})
+	}
+}
+
+// newTestTFServing returns a TF Serving that predicts [sum, first column] of each row,
+// or only the sum if scalar is true, and counts requests.
+func newTestTFServing(t *testing.T, requests *int32, scalar bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.URL.Path != "/v1/models/model1/versions/1:predict" {
+			t.Errorf("invalid path=%s", r.URL.Path)
+		}
+		var req struct {
+			Instances [][]float64 `json:"instances"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode err=%v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		predictions := make([]any, len(req.Instances))
+		for i, row := range req.Instances {
+			sum := 0.0
+			for _, v := range row {
+				sum += v
+			}
+			if scalar {
+				predictions[i] = sum
+			} else {
+				predictions[i] = []float64{sum, row[0]}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"predictions": predictions})
+	}))
+}
+
+func TestTFServingPCPredictor_PredictBatch(t *testing.T) {
+	status := newNodeStatus(10, 20)
+	status.SetFloat(NodeStatusStaticPressureDiff, 0)
+	status.SetInt(NodeStatusLogicalProcessors, 4)
+	rr := []ResourceRequest{{CPUMilli: 0}, {CPUMilli: 1000}, {CPUMilli: 2000}}
+
+	tests := []struct {
+		name        string
+		scalar      bool
+		outputIndex int
+		want        []float64
+		wantErr     bool
+	}{
+		// cpuUsage=10+requestCPU/4000, ambientTemp=20, staticPressureDiff=0
+		{"scalar", true, 0, []float64{30, 30.25, 30.5}, false},
+		{"array", false, 0, []float64{30, 30.25, 30.5}, false},
+		{"array_outputIndex", false, 1, []float64{10, 10.25, 10.5}, false},
+		{"array_outputIndex_out_of_range", false, 2, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			sv := newTestTFServing(t, &requests, tt.scalar)
+			defer sv.Close()
+
+			p, err := NewTFServingPCPredictorFromURL(sv.URL + "/v1/models/model1/versions/1:predict")
+			if err != nil {
+				t.Fatalf("NewTFServingPCPredictorFromURL() error = %v", err)
+			}
+			p.OutputIndex = tt.outputIndex
+			got, err := p.PredictBatch(context.Background(), rr, status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TFServingPCPredictor.PredictBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TFServingPCPredictor.PredictBatch() = %v, want %v", got, tt.want)
+			}
+			if requests != 1 {
+				t.Errorf("TFServingPCPredictor.PredictBatch() sent %d requests, want 1", requests)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 
 	middleware "github.com/deepmap/oapi-codegen/pkg/chi-middleware"
@@ -43,7 +45,8 @@ func (s *Server) PostNamespacesNsEstimatorsNameValuesPowerconsumption(ctx contex
 			Message: fmt.Sprintf("estimator %v/%v not found", request.Ns, request.Name),
 		}, nil
 	}
-	wattIncrease, err := e.EstimatePowerConsumption(ctx, request.Body.CpuMilli, request.Body.NumWorkloads)
+	resourceRequest := NewResourceRequest(request.Body.CpuMilli, request.Body.MemoryBytes, request.Body.ExtendedResources)
+	estimate, err := e.EstimatePowerConsumptionResources(ctx, resourceRequest, request.Body.NumWorkloads)
 	if err != nil {
 		switch {
 		// 400
@@ -54,35 +57,198 @@ func (s *Server) PostNamespacesNsEstimatorsNameValuesPowerconsumption(ctx contex
 			}, nil
 		// 500
 		default:
-			unwrappedErr := err
-			if _, ok := getErrorFromCode[err.Error()]; !ok {
-				// wrapped or unexpected
-				unwrappedErr = errors.Unwrap(err)
-				if unwrappedErr == nil {
-					unwrappedErr = ErrUnexpected
-				}
-			}
 			return api.PostNamespacesNsEstimatorsNameValuesPowerconsumption500JSONResponse{
-				Code:    unwrappedErr.Error(),
+				Code:    errorCode(err),
 				Message: err.Error(),
 			}, nil
 		}
 	}
 
 	// HACK: replace math.Inf(1) to math.MaxFloat64 to avoid jsonify failure (see also: client.go)
-	for i := range wattIncrease {
-		if wattIncrease[i] == math.Inf(1) {
-			wattIncrease[i] = math.MaxFloat64
+	wattIncrease := estimate.WattIncreases
+	for _, vs := range [][]float64{wattIncrease, estimate.WattIncreasesLower, estimate.WattIncreasesUpper} {
+		for i := range vs {
+			if vs[i] == math.Inf(1) {
+				vs[i] = math.MaxFloat64
+			}
 		}
 	}
 
 	return api.PostNamespacesNsEstimatorsNameValuesPowerconsumption200JSONResponse{
-		CpuMilli:      request.Body.CpuMilli,
-		NumWorkloads:  request.Body.NumWorkloads,
-		WattIncreases: &wattIncrease,
+		CpuMilli:           request.Body.CpuMilli,
+		MemoryBytes:        request.Body.MemoryBytes,
+		ExtendedResources:  request.Body.ExtendedResources,
+		NumWorkloads:       request.Body.NumWorkloads,
+		WattIncreases:      &wattIncrease,
+		WattIncreasesLower: toAPIBounds(estimate.WattIncreasesLower),
+		WattIncreasesUpper: toAPIBounds(estimate.WattIncreasesUpper),
+		Placements:         &estimate.Placements,
+		Predictors:         toAPIPredictors(estimate.Predictors),
+		Approximate:        &estimate.Approximate,
+	}, nil
+}
+
+func (s *Server) PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups(ctx context.Context, request api.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsRequestObject) (api.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroupsResponseObject, error) {
+	s.initOnce()
+
+	e, ok := s.Estimators.Get(client.ObjectKey{Namespace: request.Ns, Name: request.Name}.String())
+	if !ok {
+		return api.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups404JSONResponse{
+			Code:    ErrServerEstimatorNotFound.Error(),
+			Message: fmt.Sprintf("estimator %v/%v not found", request.Ns, request.Name),
+		}, nil
+	}
+	estimate, err := e.EstimatePowerConsumptionGroups(ctx, request.Body.Workloads)
+	if err != nil {
+		switch {
+		// 400
+		case errors.Is(err, ErrEstimatorInvalidRequest):
+			return api.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups400JSONResponse{
+				Code:    ErrEstimatorInvalidRequest.Error(),
+				Message: err.Error(),
+			}, nil
+		// 500
+		default:
+			return api.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups500JSONResponse{
+				Code:    errorCode(err),
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	// HACK: replace math.Inf(1) to math.MaxFloat64 to avoid jsonify failure (see also: client.go)
+	wattIncrease := estimate.WattIncrease
+	for _, v := range []*float64{&wattIncrease, estimate.WattIncreaseLower, estimate.WattIncreaseUpper} {
+		if v != nil && *v == math.Inf(1) {
+			*v = math.MaxFloat64
+		}
+	}
+
+	return api.PostNamespacesNsEstimatorsNameValuesPowerconsumptiongroups200JSONResponse{
+		Workloads:         request.Body.Workloads,
+		WattIncrease:      &wattIncrease,
+		WattIncreaseLower: estimate.WattIncreaseLower,
+		WattIncreaseUpper: estimate.WattIncreaseUpper,
+		Placements:        &estimate.Placements,
+		Predictors:        toAPIPredictors(estimate.Predictors),
 	}, nil
 }
 
+func (s *Server) GetEstimators(ctx context.Context, request api.GetEstimatorsRequestObject) (api.GetEstimatorsResponseObject, error) {
+	s.initOnce()
+
+	estimators := []api.EstimatorInfo{}
+	s.Estimators.Range(func(k string, e *Estimator) bool {
+		ns, name, _ := strings.Cut(k, "/")
+		estimators = append(estimators, toAPIEstimatorInfo(ns, name, e))
+		return true
+	})
+	sort.Slice(estimators, func(i, j int) bool {
+		if estimators[i].Namespace != estimators[j].Namespace {
+			return estimators[i].Namespace < estimators[j].Namespace
+		}
+		return estimators[i].Name < estimators[j].Name
+	})
+	return api.GetEstimators200JSONResponse{Estimators: estimators}, nil
+}
+
+func (s *Server) GetNamespacesNsEstimatorsName(ctx context.Context, request api.GetNamespacesNsEstimatorsNameRequestObject) (api.GetNamespacesNsEstimatorsNameResponseObject, error) {
+	s.initOnce()
+
+	e, ok := s.Estimators.Get(client.ObjectKey{Namespace: request.Ns, Name: request.Name}.String())
+	if !ok {
+		return api.GetNamespacesNsEstimatorsName404JSONResponse{
+			Code:    ErrServerEstimatorNotFound.Error(),
+			Message: fmt.Sprintf("estimator %v/%v not found", request.Ns, request.Name),
+		}, nil
+	}
+	return api.GetNamespacesNsEstimatorsName200JSONResponse(toAPIEstimatorInfo(request.Ns, request.Name, e)), nil
+}
+
+func (s *Server) GetNamespacesNsEstimatorsNameNodes(ctx context.Context, request api.GetNamespacesNsEstimatorsNameNodesRequestObject) (api.GetNamespacesNsEstimatorsNameNodesResponseObject, error) {
+	s.initOnce()
+
+	e, ok := s.Estimators.Get(client.ObjectKey{Namespace: request.Ns, Name: request.Name}.String())
+	if !ok {
+		return api.GetNamespacesNsEstimatorsNameNodes404JSONResponse{
+			Code:    ErrServerEstimatorNotFound.Error(),
+			Message: fmt.Sprintf("estimator %v/%v not found", request.Ns, request.Name),
+		}, nil
+	}
+	e.initOnce()
+	nodes := []api.NodeInfo{}
+	e.Nodes.Range(func(_ string, n *Node) bool {
+		nodes = append(nodes, toAPINodeInfo(n))
+		return true
+	})
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return api.GetNamespacesNsEstimatorsNameNodes200JSONResponse{Nodes: nodes}, nil
+}
+
+func (s *Server) GetNamespacesNsEstimatorsNameNodesNode(ctx context.Context, request api.GetNamespacesNsEstimatorsNameNodesNodeRequestObject) (api.GetNamespacesNsEstimatorsNameNodesNodeResponseObject, error) {
+	s.initOnce()
+
+	e, ok := s.Estimators.Get(client.ObjectKey{Namespace: request.Ns, Name: request.Name}.String())
+	if !ok {
+		return api.GetNamespacesNsEstimatorsNameNodesNode404JSONResponse{
+			Code:    ErrServerEstimatorNotFound.Error(),
+			Message: fmt.Sprintf("estimator %v/%v not found", request.Ns, request.Name),
+		}, nil
+	}
+	e.initOnce()
+	n, ok := e.Nodes.Get(request.Node)
+	if !ok {
+		return api.GetNamespacesNsEstimatorsNameNodesNode404JSONResponse{
+			Code:    ErrServerNodeNotFound.Error(),
+			Message: fmt.Sprintf("node %v not found in estimator %v/%v", request.Node, request.Ns, request.Name),
+		}, nil
+	}
+	return api.GetNamespacesNsEstimatorsNameNodesNode200JSONResponse(toAPINodeInfo(n)), nil
+}
+
+func (s *Server) GetNamespacesNsEstimatorsNameNodesNodeStatushistory(ctx context.Context, request api.GetNamespacesNsEstimatorsNameNodesNodeStatushistoryRequestObject) (api.GetNamespacesNsEstimatorsNameNodesNodeStatushistoryResponseObject, error) {
+	s.initOnce()
+
+	e, ok := s.Estimators.Get(client.ObjectKey{Namespace: request.Ns, Name: request.Name}.String())
+	if !ok {
+		return api.GetNamespacesNsEstimatorsNameNodesNodeStatushistory404JSONResponse{
+			Code:    ErrServerEstimatorNotFound.Error(),
+			Message: fmt.Sprintf("estimator %v/%v not found", request.Ns, request.Name),
+		}, nil
+	}
+	e.initOnce()
+	n, ok := e.Nodes.Get(request.Node)
+	if !ok {
+		return api.GetNamespacesNsEstimatorsNameNodesNodeStatushistory404JSONResponse{
+			Code:    ErrServerNodeNotFound.Error(),
+			Message: fmt.Sprintf("node %v not found in estimator %v/%v", request.Node, request.Ns, request.Name),
+		}, nil
+	}
+
+	history := n.GetStatusHistory()
+	snapshots := make([]api.NodeStatusSnapshot, len(history))
+	for i, status := range history {
+		snapshots[i] = toAPINodeStatusSnapshot(status)
+	}
+	return api.GetNamespacesNsEstimatorsNameNodesNodeStatushistory200JSONResponse{
+		Node:      request.Node,
+		Snapshots: snapshots,
+	}, nil
+}
+
+// errorCode returns the code of the given error, i.e. the error itself or the wrapped one.
+func errorCode(err error) string {
+	unwrappedErr := err
+	if _, ok := getErrorFromCode[err.Error()]; !ok {
+		// wrapped or unexpected
+		unwrappedErr = errors.Unwrap(err)
+		if unwrappedErr == nil {
+			unwrappedErr = ErrUnexpected
+		}
+	}
+	return unwrappedErr.Error()
+}
+
 func RequestToEstimatorName(ns, name string) string {
 	return client.ObjectKey{Namespace: ns, Name: name}.String()
 }
@@ -133,3 +299,94 @@ func (s *Server) HandlerWithAuthFn(authFn AuthenticationFunc, middlewares ...fun
 		}))
 	return api.HandlerFromMux(h, r), nil
 }
+
+// toAPIPredictors omits predictors in the response if no nodes report them.
+func toAPIPredictors(predictors map[string]string) *map[string]string {
+	if len(predictors) == 0 {
+		return nil
+	}
+	return &predictors
+}
+
+// toAPIBounds omits the bounds in the response if no nodes report them.
+func toAPIBounds(bounds []float64) *[]float64 {
+	if bounds == nil {
+		return nil
+	}
+	return &bounds
+}
+
+// toAPIEstimatorInfo summarizes the nodes of the Estimator, monitors and predictors are deduplicated and sorted.
+func toAPIEstimatorInfo(ns, name string, e *Estimator) api.EstimatorInfo {
+	e.initOnce()
+
+	monitors := map[string]struct{}{}
+	predictors := map[string]struct{}{}
+	e.Nodes.Range(func(_ string, n *Node) bool {
+		for _, m := range n.MonitorNames() {
+			monitors[m] = struct{}{}
+		}
+		if p := n.PredictorName(); p != "" {
+			predictors[p] = struct{}{}
+		}
+		return true
+	})
+	info := api.EstimatorInfo{
+		Namespace:  ns,
+		Name:       name,
+		Nodes:      e.Nodes.Len(),
+		Ready:      e.Ready(),
+		Monitors:   sortedKeys(monitors),
+		Predictors: sortedKeys(predictors),
+	}
+	if e.PredictionCache != nil {
+		stats := e.PredictionCache.Stats()
+		info.PredictionCache = &api.PredictionCacheStats{
+			Hits:   int64(stats.Hits),
+			Misses: int64(stats.Misses),
+			Len:    stats.Len,
+		}
+	}
+	return info
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toAPINodeInfo(n *Node) api.NodeInfo {
+	info := api.NodeInfo{
+		Name:     n.Name,
+		Monitors: n.MonitorNames(),
+		Status:   toAPINodeStatusSnapshot(n.GetStatus()),
+		Errors:   []api.NodeError{},
+	}
+	if predictor := n.PredictorName(); predictor != "" {
+		info.Predictor = &predictor
+	}
+	for _, e := range n.LastErrors() {
+		info.Errors = append(info.Errors, api.NodeError{Source: e.Source, Message: e.Message, Timestamp: e.Timestamp})
+	}
+	return info
+}
+
+func toAPINodeStatusSnapshot(status *NodeStatus) api.NodeStatusSnapshot {
+	values := map[string]api.NodeStatusEntry{}
+	for k, e := range status.Entries() {
+		source := e.Source
+		stale := status.stale(k, e)
+		values[string(k)] = api.NodeStatusEntry{
+			Type:      api.NodeStatusEntryType(e.Value.Type.String()),
+			Value:     e.Value.Float(),
+			Timestamp: e.Timestamp,
+			Source:    &source,
+			Stale:     &stale,
+		}
+	}
+	return api.NodeStatusSnapshot{Timestamp: status.Timestamp(), Values: values}
+}
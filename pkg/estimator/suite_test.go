@@ -13,8 +13,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
 
 	"github.com/Nedopro2022/wao-estimator/pkg/estimator"
+	"github.com/Nedopro2022/wao-estimator/pkg/estimator/api"
 )
 
 func TestAPIs(t *testing.T) {
@@ -184,16 +186,22 @@ var _ = Describe("Server/Client", func() {
 			CpuMilli: 500, NumWorkloads: 5,
 		}, &estimator.PowerConsumption{
 			CpuMilli: 500, NumWorkloads: 5, WattIncreases: &[]float64{math.Inf(1), math.Inf(1), math.Inf(1), math.Inf(1), math.Inf(1)},
+			Placements:  &[][]map[string]int{{}, {}, {}, {}, {}},
+			Approximate: pointer.Bool(false),
 		}, nil)
 		testRequest(cl, &estimator.PowerConsumption{
 			CpuMilli: 1000, NumWorkloads: 1,
 		}, &estimator.PowerConsumption{
 			CpuMilli: 1000, NumWorkloads: 1, WattIncreases: &[]float64{math.Inf(1)},
+			Placements:  &[][]map[string]int{{}},
+			Approximate: pointer.Bool(false),
 		}, nil)
 		testRequest(cl, &estimator.PowerConsumption{
 			CpuMilli: 1000, NumWorkloads: 0,
 		}, &estimator.PowerConsumption{
 			CpuMilli: 1000, NumWorkloads: 0, WattIncreases: &[]float64{},
+			Placements:  &[][]map[string]int{},
+			Approximate: pointer.Bool(false),
 		}, nil)
 
 		// test: n0, n1 (fake)
@@ -210,6 +218,8 @@ var _ = Describe("Server/Client", func() {
 			CpuMilli: 500, NumWorkloads: 4,
 		}, &estimator.PowerConsumption{
 			CpuMilli: 500, NumWorkloads: 4, WattIncreases: &[]float64{5, 10, 15, 20},
+			Placements:  &[][]map[string]int{{{"n1": 1}}, {{"n1": 2}}, {{"n1": 3}}, {{"n1": 4}}},
+			Approximate: pointer.Bool(false),
 		}, nil)
 
 		// test: n0, n1, n2 (fake)
@@ -227,7 +237,65 @@ var _ = Describe("Server/Client", func() {
 			CpuMilli: 500, NumWorkloads: 4,
 		}, &estimator.PowerConsumption{
 			CpuMilli: 500, NumWorkloads: 4, WattIncreases: &[]float64{2.5, 5, 7.5, 10},
+			Placements:  &[][]map[string]int{{{"n2": 1}}, {{"n2": 2}}, {{"n2": 3}}, {{"n2": 4}}},
+			Approximate: pointer.Bool(false),
 		}, nil)
+		// 1x4000m + 2x500m -> n2: 5000/200
+		testRequestGroups(cl, []estimator.WorkloadGroup{{CpuMilli: 4000, Count: 1}, {CpuMilli: 500, Count: 2}}, &estimator.PowerConsumptionGroups{
+			Workloads:    []estimator.WorkloadGroup{{CpuMilli: 4000, Count: 1}, {CpuMilli: 500, Count: 2}},
+			WattIncrease: pointer.Float64(25),
+			Placements:   &[]map[string][]int{{"n2": {1, 2}}},
+		}, nil)
+		testRequestGroups(cl, []estimator.WorkloadGroup{{CpuMilli: -1, Count: 1}}, nil, estimator.ErrEstimatorInvalidRequest)
+
+		// test: estimators
+		sv.Estimators.Add(estimator.RequestToEstimatorName("a", "b"), &estimator.Estimator{PredictionCache: estimator.NewPCPredictionCache(0, 0)})
+		estimators, apiErr, err := cl.ListEstimators(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).To(BeNil())
+		Expect(estimators.Estimators).To(Equal([]estimator.EstimatorInfo{
+			{Namespace: "a", Name: "b", Nodes: 0, Ready: false, Monitors: []string{}, Predictors: []string{}, PredictionCache: &api.PredictionCacheStats{}},
+			{Namespace: ns, Name: name, Nodes: 3, Ready: true, Monitors: []string{"FakeNodeMonitor"}, Predictors: []string{"FakePCPredictor"}},
+		}))
+		info, apiErr, err := cl.GetEstimator(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).To(BeNil())
+		Expect(*info).To(Equal(estimators.Estimators[1]))
+		clX, err := estimator.NewClient(httpAddr, "x", "y")
+		Expect(err).NotTo(HaveOccurred())
+		_, apiErr, err = clX.GetEstimator(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).NotTo(BeNil())
+		Expect(estimator.GetErrorFromCode(*apiErr)).To(MatchError(estimator.ErrServerEstimatorNotFound))
+
+		// test: nodes
+		nodes, apiErr, err := cl.ListNodes(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).To(BeNil())
+		Expect(nodes.Nodes).To(HaveLen(3))
+		Expect([]string{nodes.Nodes[0].Name, nodes.Nodes[1].Name, nodes.Nodes[2].Name}).To(Equal([]string{"n0", "n1", "n2"}))
+		Expect(nodes.Nodes[0].Predictor).To(BeNil())
+		node, apiErr, err := cl.GetNode(context.Background(), "n1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).To(BeNil())
+		Expect(node.Monitors).To(Equal([]string{"FakeNodeMonitor"}))
+		Expect(node.Predictor).To(Equal(pointer.String("FakePCPredictor")))
+		Expect(node.Errors).To(BeEmpty())
+		_, apiErr, err = cl.GetNode(context.Background(), "nX")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).NotTo(BeNil())
+		Expect(estimator.GetErrorFromCode(*apiErr)).To(MatchError(estimator.ErrServerNodeNotFound))
+
+		// test: NodeStatus history
+		history, apiErr, err := cl.GetNodeStatusHistory(context.Background(), "n1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).To(BeNil())
+		Expect(history.Node).To(Equal("n1"))
+		Expect(history.Snapshots).NotTo(BeEmpty())
+		_, apiErr, err = cl.GetNodeStatusHistory(context.Background(), "nX")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiErr).NotTo(BeNil())
+		Expect(estimator.GetErrorFromCode(*apiErr)).To(MatchError(estimator.ErrServerNodeNotFound))
 
 	})
 
@@ -297,7 +365,35 @@ func testRequest(cl *estimator.Client, req, want *estimator.PowerConsumption, wa
 		}
 
 		if !reflect.DeepEqual(pc, want) {
-			return fmt.Errorf("want=%v (WattIncreases=%v) but got %v (WattIncreases=%v)", want, want.WattIncreases, pc, pc.WattIncreases)
+			return fmt.Errorf("want=%v (WattIncreases=%v Placements=%v) but got %v (WattIncreases=%v Placements=%v)", want, want.WattIncreases, want.Placements, pc, pc.WattIncreases, pc.Placements)
+		}
+
+		return nil
+	}
+
+	Eventually(testFn).Should(Succeed())
+}
+
+func testRequestGroups(cl *estimator.Client, workloads []estimator.WorkloadGroup, want *estimator.PowerConsumptionGroups, wantAPIErr error) {
+	testFn := func() error {
+		pc, apiErr, err := cl.EstimatePowerConsumptionGroups(context.Background(), workloads)
+
+		if err != nil {
+			return fmt.Errorf("err=%v", err)
+		}
+
+		if wantAPIErr != nil {
+			if apiErr == nil || pc != nil || err != nil {
+				return fmt.Errorf("wantAPIErr=%v but got %v, pc=%v err=%v", wantAPIErr, apiErr, pc, err)
+			}
+			if !errors.Is(estimator.GetErrorFromCode(*apiErr), wantAPIErr) {
+				return fmt.Errorf("wantAPIErr=%v but got %v, pc=%v err=%v", wantAPIErr, apiErr, pc, err)
+			}
+			return nil
+		}
+
+		if !reflect.DeepEqual(pc, want) {
+			return fmt.Errorf("want=%v (WattIncrease=%v Placements=%v) but got %v (WattIncrease=%v Placements=%v)", want, want.WattIncrease, want.Placements, pc, pc.WattIncrease, pc.Placements)
 		}
 
 		return nil
@@ -374,7 +470,7 @@ func setNodeStatus(base *estimator.NodeStatus, cpuUsage, ambientTemp float64) *e
 	if base == nil {
 		base = estimator.NewNodeStatus()
 	}
-	estimator.NodeStatusSetCPUUsage(base, cpuUsage)
-	estimator.NodeStatusSetAmbientTemp(base, ambientTemp)
+	base.SetFloat(estimator.NodeStatusCPUUsage, cpuUsage)
+	base.SetFloat(estimator.NodeStatusAmbientTemp, ambientTemp)
 	return base
 }